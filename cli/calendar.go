@@ -0,0 +1,216 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	ICS_LINE_MAX_SIZE = 75
+	ICS_TIME_FORMAT   = "20060102T150405Z"
+	ICS_DATE_FORMAT   = "20060102"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// expiryEvent contains info about certificate expiry
+type expiryEvent struct {
+	SHA256Hash string
+	Subject    string
+	Issuer     string
+	NotAfter   time.Time
+	Hosts      []string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// expiryEvents contains expiry events for all checked certificates
+var expiryEvents []*expiryEvent
+
+// calendarAlarms contains list of durations for reminders before expiry
+var calendarAlarms []time.Duration
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseCalendarAlarms parses comma-separated list of alarm durations
+func parseCalendarAlarms() error {
+	for _, alarm := range strings.Split(options.GetS(OPT_ALARMS), ",") {
+		alarm = strings.TrimSpace(alarm)
+
+		if alarm == "" {
+			continue
+		}
+
+		dur, err := timeutil.ParseDuration(alarm, 'd')
+
+		if err != nil {
+			return fmt.Errorf("Can't parse alarm duration %q: %w", alarm, err)
+		}
+
+		calendarAlarms = append(calendarAlarms, dur)
+	}
+
+	return nil
+}
+
+// collectExpiryEvents adds expiry events for leaf certificates served by host
+func collectExpiryEvents(host string, info *sslscan.AnalyzeInfo) {
+	if info == nil || info.Status != sslscan.STATUS_READY {
+		return
+	}
+
+	for _, endpoint := range info.Endpoints {
+		cert := getEndpointCert(info, endpoint)
+
+		if cert == nil || cert.SHA256Hash == "" {
+			continue
+		}
+
+		addExpiryEvent(host, cert)
+	}
+}
+
+// addExpiryEvent adds expiry event for certificate or adds host to existing
+// event if certificate is shared between hosts
+func addExpiryEvent(host string, cert *sslscan.Cert) {
+	for _, event := range expiryEvents {
+		if event.SHA256Hash != cert.SHA256Hash {
+			continue
+		}
+
+		if !slices.Contains(event.Hosts, host) {
+			event.Hosts = append(event.Hosts, host)
+		}
+
+		return
+	}
+
+	expiryEvents = append(expiryEvents, &expiryEvent{
+		SHA256Hash: cert.SHA256Hash,
+		Subject:    extractSubject(cert.Subject),
+		Issuer:     extractSubject(cert.IssuerSubject),
+		NotAfter:   time.Unix(cert.NotAfter/1000, 0).UTC(),
+		Hosts:      []string{host},
+	})
+}
+
+// saveCalendar saves expiry events to file in iCalendar format
+func saveCalendar(file string) error {
+	err := os.WriteFile(file, []byte(renderCalendar(expiryEvents, time.Now())), 0644)
+
+	if err != nil {
+		return fmt.Errorf("Can't save expiry calendar: %w", err)
+	}
+
+	return nil
+}
+
+// renderCalendar renders expiry events as iCalendar (RFC 5545) data
+func renderCalendar(events []*expiryEvent, now time.Time) string {
+	var buf strings.Builder
+
+	events = slices.SortedFunc(slices.Values(events), func(a, b *expiryEvent) int {
+		return cmp.Compare(a.NotAfter.Unix(), b.NotAfter.Unix())
+	})
+
+	stamp := now.UTC().Format(ICS_TIME_FORMAT)
+
+	writeICSLine(&buf, "BEGIN:VCALENDAR")
+	writeICSLine(&buf, "VERSION:2.0")
+	writeICSLine(&buf, "PRODID:-//ESSENTIAL KAOS//SSLCli "+VER+"//EN")
+	writeICSLine(&buf, "CALSCALE:GREGORIAN")
+	writeICSLine(&buf, "METHOD:PUBLISH")
+	writeICSLine(&buf, "X-WR-CALNAME:Certificate expiry")
+
+	for _, event := range events {
+		summary := fmt.Sprintf("Certificate for %s expires", strings.Join(event.Hosts, ", "))
+		description := fmt.Sprintf(
+			"Subject: %s\nIssuer: %s\nExpires: %s\nFingerprint: %s\nHosts: %s",
+			event.Subject, event.Issuer, event.NotAfter.Format(time.RFC3339),
+			event.SHA256Hash, strings.Join(event.Hosts, ", "),
+		)
+
+		writeICSLine(&buf, "BEGIN:VEVENT")
+		writeICSLine(&buf, "UID:"+event.SHA256Hash+"@sslcli")
+		writeICSLine(&buf, "DTSTAMP:"+stamp)
+		writeICSLine(&buf, "DTSTART;VALUE=DATE:"+event.NotAfter.Format(ICS_DATE_FORMAT))
+		writeICSLine(&buf, "DTEND;VALUE=DATE:"+event.NotAfter.AddDate(0, 0, 1).Format(ICS_DATE_FORMAT))
+		writeICSLine(&buf, "SUMMARY:"+escapeICSText(summary))
+		writeICSLine(&buf, "DESCRIPTION:"+escapeICSText(description))
+		writeICSLine(&buf, "TRANSP:TRANSPARENT")
+
+		for _, alarm := range calendarAlarms {
+			writeICSLine(&buf, "BEGIN:VALARM")
+			writeICSLine(&buf, "ACTION:DISPLAY")
+			writeICSLine(&buf, "TRIGGER:-"+formatICSDuration(alarm))
+			writeICSLine(&buf, "DESCRIPTION:"+escapeICSText(summary))
+			writeICSLine(&buf, "END:VALARM")
+		}
+
+		writeICSLine(&buf, "END:VEVENT")
+	}
+
+	writeICSLine(&buf, "END:VCALENDAR")
+
+	return buf.String()
+}
+
+// writeICSLine writes content line folded to lines with maximum length of
+// 75 octets
+func writeICSLine(buf *strings.Builder, line string) {
+	size := ICS_LINE_MAX_SIZE
+
+	for len(line) > size {
+		cut := size
+
+		// Don't split multi-byte characters
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+
+		buf.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+
+		// Continuation lines start with space
+		size = ICS_LINE_MAX_SIZE - 1
+	}
+
+	buf.WriteString(line + "\r\n")
+}
+
+// escapeICSText escapes special characters in text value
+func escapeICSText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`,
+	).Replace(text)
+}
+
+// formatICSDuration formats duration as iCalendar duration value
+func formatICSDuration(dur time.Duration) string {
+	switch {
+	case dur%(24*time.Hour) == 0:
+		return fmt.Sprintf("P%dD", dur/(24*time.Hour))
+	case dur%time.Hour == 0:
+		return fmt.Sprintf("PT%dH", dur/time.Hour)
+	}
+
+	return fmt.Sprintf("PT%dM", dur/time.Minute)
+}
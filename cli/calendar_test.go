@@ -0,0 +1,168 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestWriteICSLine(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Line     string
+		Expected string
+	}{
+		{"short", "BEGIN:VEVENT", "BEGIN:VEVENT\r\n"},
+		{"max size", strings.Repeat("a", 75), strings.Repeat("a", 75) + "\r\n"},
+		{
+			"folded once", strings.Repeat("a", 76),
+			strings.Repeat("a", 75) + "\r\n a\r\n",
+		},
+		{
+			"folded twice", strings.Repeat("a", 75+74+1),
+			strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n a\r\n",
+		},
+		{
+			"multi-byte", strings.Repeat("a", 74) + "ёё",
+			strings.Repeat("a", 74) + "\r\n ёё\r\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var buf strings.Builder
+
+			writeICSLine(&buf, tc.Line)
+
+			if buf.String() != tc.Expected {
+				t.Errorf("Expected %q, got %q", tc.Expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestWriteICSLineLimits(t *testing.T) {
+	var buf strings.Builder
+
+	line := "DESCRIPTION:" + strings.Repeat("Сертификат ✓ ", 40)
+
+	writeICSLine(&buf, line)
+
+	data := strings.TrimSuffix(buf.String(), "\r\n")
+
+	for _, l := range strings.Split(data, "\r\n") {
+		if len(l) > ICS_LINE_MAX_SIZE {
+			t.Errorf("Line %q is longer than %d octets", l, ICS_LINE_MAX_SIZE)
+		}
+
+		if !utf8.ValidString(l) {
+			t.Errorf("Line %q contains split multi-byte character", l)
+		}
+	}
+
+	if unfolded := strings.ReplaceAll(data, "\r\n ", ""); unfolded != line {
+		t.Errorf("Unfolded line %q doesn't match original %q", unfolded, line)
+	}
+}
+
+func TestEscapeICSText(t *testing.T) {
+	cases := []struct {
+		Text     string
+		Expected string
+	}{
+		{"domain.com", "domain.com"},
+		{"a, b; c", `a\, b\; c`},
+		{`C:\path`, `C:\\path`},
+		{"line1\nline2", `line1\nline2`},
+		{`\,`, `\\\,`},
+	}
+
+	for _, tc := range cases {
+		if result := escapeICSText(tc.Text); result != tc.Expected {
+			t.Errorf("Expected %q for %q, got %q", tc.Expected, tc.Text, result)
+		}
+	}
+}
+
+func TestFormatICSDuration(t *testing.T) {
+	cases := []struct {
+		Duration time.Duration
+		Expected string
+	}{
+		{30 * 24 * time.Hour, "P30D"},
+		{24 * time.Hour, "P1D"},
+		{12 * time.Hour, "PT12H"},
+		{90 * time.Minute, "PT90M"},
+	}
+
+	for _, tc := range cases {
+		if result := formatICSDuration(tc.Duration); result != tc.Expected {
+			t.Errorf("Expected %q for %v, got %q", tc.Expected, tc.Duration, result)
+		}
+	}
+}
+
+func TestRenderCalendar(t *testing.T) {
+	alarms := calendarAlarms
+	calendarAlarms = []time.Duration{7 * 24 * time.Hour}
+
+	defer func() { calendarAlarms = alarms }()
+
+	events := []*expiryEvent{
+		{
+			SHA256Hash: "bbbb",
+			Subject:    "b.domain.com",
+			Issuer:     "R3",
+			NotAfter:   time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+			Hosts:      []string{"b.domain.com"},
+		},
+		{
+			SHA256Hash: "aaaa",
+			Subject:    "a.domain.com",
+			Issuer:     "R3",
+			NotAfter:   time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC),
+			Hosts:      []string{"a.domain.com", "www.domain.com"},
+		},
+	}
+
+	data := renderCalendar(events, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC))
+
+	if !strings.HasPrefix(data, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(data, "END:VCALENDAR\r\n") {
+		t.Fatalf("Calendar has invalid start or end:\n%s", data)
+	}
+
+	if strings.Count(data, "\n") != strings.Count(data, "\r\n") {
+		t.Error("Calendar contains lines without CRLF")
+	}
+
+	for _, line := range []string{
+		"UID:aaaa@sslcli",
+		"DTSTAMP:20251201T000000Z",
+		"DTSTART;VALUE=DATE:20260131",
+		"DTEND;VALUE=DATE:20260201",
+		`SUMMARY:Certificate for a.domain.com\, www.domain.com expires`,
+		"TRIGGER:-P7D",
+	} {
+		if !strings.Contains(data, "\r\n"+line+"\r\n") {
+			t.Errorf("Calendar doesn't contain line %q", line)
+		}
+	}
+
+	if strings.Count(data, "BEGIN:VALARM") != 2 {
+		t.Errorf("Expected 2 alarms, got %d", strings.Count(data, "BEGIN:VALARM"))
+	}
+
+	if strings.Index(data, "UID:aaaa") > strings.Index(data, "UID:bbbb") {
+		t.Error("Events are not sorted by expiry date")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"slices"
 	"strings"
 	"time"
 
@@ -47,6 +48,7 @@ const (
 const (
 	OPT_EMAIL           = "e:email"
 	OPT_FORMAT          = "f:format"
+	OPT_COLUMNS         = "columns"
 	OPT_DETAILED        = "d:detailed"
 	OPT_IGNORE_MISMATCH = "i:ignore-mismatch"
 	OPT_AVOID_CACHE     = "c:avoid-cache"
@@ -56,6 +58,27 @@ const (
 	OPT_QUIET           = "q:quiet"
 	OPT_NOTIFY          = "n:notify"
 	OPT_PAGER           = "G:pager"
+	OPT_TUI             = "T:tui"
+	OPT_PROFILE         = "profile"
+	OPT_RETRIES         = "R:retries"
+	OPT_RETRY_DEADLINE  = "retry-deadline"
+	OPT_TIMEOUT         = "t:timeout"
+	OPT_DEADLINE        = "deadline"
+	OPT_IMPORT          = "I:import"
+	OPT_FILTER          = "F:filter"
+	OPT_INVENTORY       = "inventory"
+	OPT_SAN_AUDIT       = "san-audit"
+	OPT_CALENDAR        = "calendar"
+	OPT_SAVE_REPORTS    = "save-reports"
+	OPT_RESUME_FILE     = "resume-file"
+	OPT_ALARMS          = "alarms"
+	OPT_ALLOWED_ISSUERS = "allowed-issuers"
+	OPT_BANNED_KEYS     = "banned-keys"
+	OPT_MAX_VALIDITY    = "max-validity"
+	OPT_REQUIRE_CT      = "require-ct"
+	OPT_REQUIRE_CAA     = "require-caa"
+	OPT_REQUIRE_HEADERS = "require-headers"
+	OPT_SUITE_PROFILE   = "suite-profile"
 	OPT_NO_COLOR        = "nc:no-color"
 	OPT_HELP            = "h:help"
 	OPT_VER             = "v:version"
@@ -69,16 +92,44 @@ const (
 	OPT_GENERATE_MAN = "generate-man"
 )
 
+const (
+	CMD_COMPARE = "compare"
+)
+
 const (
 	DELAY_PRE_CHECK = 2 * time.Second
 	DELAY_PROGRESS  = 6 * time.Second
 )
 
+// Exit codes (check results are combined as a bitmask)
+const (
+	EC_OK         = 0
+	EC_ERROR      = 1  // Usage or any other error
+	EC_GRADE      = 2  // Grade is lower than A
+	EC_EXPIRY     = 4  // Certificate expires soon
+	EC_POLICY     = 8  // Policy violation (grade is lower than A+ with -P, issuance policy)
+	EC_ASSESSMENT = 16 // Assessment finished with error
+	EC_API        = 32 // SSL Labs API is unavailable
+	EC_TIMEOUT    = 64 // Assessment didn't finish in time
+
+	// EC_DIFF is used only by compare command instead of EC_GRADE
+	EC_DIFF = 2 // Compared hosts have differences
+
+	// EC_CANCELLED is exclusive and never combined with other codes
+	EC_CANCELLED = 128 // Check cancelled by user
+)
+
 const (
 	FORMAT_TEXT = "text"
 	FORMAT_YAML = "yaml"
 	FORMAT_JSON = "json"
 	FORMAT_XML  = "xml"
+	FORMAT_HTML = "html"
+	FORMAT_MD   = "markdown"
+	FORMAT_CSV  = "csv"
+	FORMAT_TSV  = "tsv"
+
+	FORMAT_NDJSON = "ndjson"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -90,12 +141,23 @@ type HostCheckInfo struct {
 	LowestGradeNum  float64              `json:"lowestGradeNum"`
 	HighestGradeNum float64              `json:"highestGradeNum"`
 	Endpoints       []*EndpointCheckInfo `json:"endpoints"`
+	Error           *CheckError          `json:"error,omitempty"`
+	Retries         int                  `json:"retries"`
+	Tags            map[string]string    `json:"tags,omitempty"`
+	Violations      []string             `json:"violations,omitempty"`
+	HSTSPreload     *PreloadReadiness    `json:"hstsPreload,omitempty"`
+
+	info        *sslscan.AnalyzeInfo // Full assessment info
+	expiredSoon bool                 // Certificate expires soon
 }
 
 type EndpointCheckInfo struct {
-	IPAddress string  `json:"ipAddress"`
-	Grade     string  `json:"grade"`
-	GradeNum  float64 `json:"gradeNum"`
+	IPAddress       string               `json:"ipAddress"`
+	Grade           string               `json:"grade"`
+	GradeNum        float64              `json:"gradeNum"`
+	ExpiringCerts   []*ExpiringCert      `json:"expiringCerts,omitempty"`
+	SecurityHeaders []*HeaderCheck       `json:"securityHeaders,omitempty"`
+	SuitesOrder     []*SuiteOrderProblem `json:"suitesOrder,omitempty"`
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -103,6 +165,7 @@ type EndpointCheckInfo struct {
 var optMap = options.Map{
 	OPT_EMAIL:           {},
 	OPT_FORMAT:          {},
+	OPT_COLUMNS:         {},
 	OPT_MAX_LEFT:        {},
 	OPT_DETAILED:        {Type: options.BOOL},
 	OPT_IGNORE_MISMATCH: {Type: options.BOOL},
@@ -112,6 +175,27 @@ var optMap = options.Map{
 	OPT_QUIET:           {Type: options.BOOL},
 	OPT_NOTIFY:          {Type: options.BOOL},
 	OPT_PAGER:           {Type: options.BOOL},
+	OPT_TUI:             {Type: options.BOOL, Conflicts: []string{OPT_FORMAT, OPT_QUIET}},
+	OPT_PROFILE:         {},
+	OPT_RETRIES:         {Type: options.INT, Value: 3, Min: 0, Max: 10},
+	OPT_RETRY_DEADLINE:  {},
+	OPT_TIMEOUT:         {},
+	OPT_DEADLINE:        {},
+	OPT_IMPORT:          {},
+	OPT_FILTER:          {},
+	OPT_INVENTORY:       {Type: options.BOOL, Conflicts: []string{OPT_TUI, OPT_QUIET}},
+	OPT_SAN_AUDIT:       {Type: options.BOOL, Conflicts: []string{OPT_TUI, OPT_QUIET, OPT_INVENTORY}},
+	OPT_CALENDAR:        {},
+	OPT_SAVE_REPORTS:    {},
+	OPT_RESUME_FILE:     {Value: RESUME_FILE},
+	OPT_ALARMS:          {Value: "30d,7d"},
+	OPT_ALLOWED_ISSUERS: {},
+	OPT_BANNED_KEYS:     {},
+	OPT_MAX_VALIDITY:    {},
+	OPT_REQUIRE_CT:      {Type: options.BOOL},
+	OPT_REQUIRE_CAA:     {Type: options.BOOL},
+	OPT_REQUIRE_HEADERS: {},
+	OPT_SUITE_PROFILE:   {},
 	OPT_NO_COLOR:        {Type: options.BOOL},
 	OPT_HELP:            {Type: options.BOOL},
 	OPT_VER:             {Type: options.MIXED},
@@ -125,6 +209,15 @@ var optMap = options.Map{
 	OPT_GENERATE_MAN: {Type: options.BOOL},
 }
 
+// outputFormats contains list of all supported output formats
+var outputFormats = []string{
+	FORMAT_TEXT, FORMAT_JSON, FORMAT_NDJSON, FORMAT_YAML, FORMAT_XML,
+	FORMAT_HTML, FORMAT_MD, FORMAT_CSV, FORMAT_TSV,
+}
+
+// reportFormats contains list of formats supported by certificate reports
+var reportFormats = []string{FORMAT_JSON, FORMAT_YAML, FORMAT_CSV, FORMAT_TSV}
+
 var gradeNumMap = map[string]float64{
 	"A+":  4.3,
 	"A":   4.0,
@@ -141,8 +234,12 @@ var gradeNumMap = map[string]float64{
 
 var api *sslscan.API
 var maxLeftToExpiry time.Duration
+var retryDeadline time.Duration
+var hostTimeout, runTimeout time.Duration
+var runDeadline time.Time
 var serverMessageShown bool
 var email string
+var exitCode int
 
 var colorTagApp, colorTagVer string
 
@@ -163,6 +260,8 @@ func Run(gitRev string, gomod []byte) {
 		os.Exit(1)
 	}
 
+	configErr := loadConfig()
+
 	configureUI()
 
 	switch {
@@ -178,7 +277,7 @@ func Run(gitRev string, gomod []byte) {
 		support.Collect(APP, VER).
 			WithRevision(gitRev).
 			WithDeps(deps.Extract(gomod)).
-			WithChecks(checkAPIAvailability()).
+			WithChecks(checkAPIAvailability(), checkConfig(configErr)).
 			Print()
 		os.Exit(0)
 	case options.GetB(OPT_HELP) || (len(args) == 0 && !options.GetB(OPT_REGISTER)):
@@ -186,6 +285,11 @@ func Run(gitRev string, gomod []byte) {
 		os.Exit(0)
 	}
 
+	if configErr != nil {
+		terminal.Error(configErr)
+		os.Exit(1)
+	}
+
 	checkForEmail()
 
 	err = prepare()
@@ -198,6 +302,8 @@ func Run(gitRev string, gomod []byte) {
 	switch {
 	case options.GetB(OPT_REGISTER):
 		err, ok = registerUser()
+	case args.Get(0).Is(CMD_COMPARE):
+		err, ok = runCompare(args[1:])
 	default:
 		err, ok = runHostCheck(args)
 	}
@@ -207,7 +313,7 @@ func Run(gitRev string, gomod []byte) {
 	}
 
 	if !ok {
-		os.Exit(1)
+		os.Exit(max(exitCode, EC_ERROR))
 	}
 }
 
@@ -232,11 +338,99 @@ func configureUI() {
 
 // prepare prepares utility for processing data
 func prepare() error {
-	if !options.Has(OPT_MAX_LEFT) {
-		return nil
+	var err error
+
+	if options.GetS(OPT_IMPORT) != "" {
+		err = validateImportType(options.GetS(OPT_IMPORT))
+
+		if err != nil {
+			return err
+		}
 	}
 
-	var err error
+	if options.GetS(OPT_FILTER) != "" {
+		err = validateHostFilter()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.GetB(OPT_INVENTORY) {
+		err = validateReportFormat("Certificate inventory")
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.GetB(OPT_SAN_AUDIT) {
+		err = validateReportFormat("SAN audit")
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.GetS(OPT_CALENDAR) != "" {
+		err = parseCalendarAlarms()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.GetS(OPT_SAVE_REPORTS) != "" && !fsutil.CheckPerms("DW", options.GetS(OPT_SAVE_REPORTS)) {
+		return fmt.Errorf("Directory %s for reports doesn't exist or isn't writable", options.GetS(OPT_SAVE_REPORTS))
+	}
+
+	err = parsePolicy()
+
+	if err != nil {
+		return err
+	}
+
+	err = validateSuiteProfile()
+
+	if err != nil {
+		return err
+	}
+
+	if options.GetS(OPT_COLUMNS) != "" {
+		err = validateCSVColumns()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.GetS(OPT_TIMEOUT) != "" {
+		hostTimeout, err = timeutil.ParseDuration(options.GetS(OPT_TIMEOUT), 's')
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.GetS(OPT_DEADLINE) != "" {
+		runTimeout, err = timeutil.ParseDuration(options.GetS(OPT_DEADLINE), 's')
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.GetS(OPT_RETRY_DEADLINE) != "" {
+		retryDeadline, err = timeutil.ParseDuration(options.GetS(OPT_RETRY_DEADLINE), 's')
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.GetS(OPT_MAX_LEFT) == "" {
+		return nil
+	}
 
 	maxLeftToExpiry, err = timeutil.ParseDuration(options.GetS(OPT_MAX_LEFT), 'd')
 
@@ -315,6 +509,27 @@ func runHostCheck(args options.Arguments) (error, bool) {
 	var ok bool
 	var err error
 	var hosts []string
+	var noHTTPS []noHTTPSHost
+
+	hosts, err = getHosts(args)
+
+	if err != nil {
+		return err, false
+	}
+
+	if isProbeRequired() {
+		hosts, noHTTPS = probeHosts(hosts)
+
+		if len(hosts) == 0 {
+			if options.GetS(OPT_FORMAT) != "" {
+				renderReport(appendSkippedHosts(nil, noHTTPS))
+			}
+
+			printNoHTTPSHosts(noHTTPS)
+
+			return errors.New("There are no hosts which serve HTTPS"), false
+		}
+	}
 
 	api, err = sslscan.NewAPI("SSLCli", VER, email)
 
@@ -323,52 +538,115 @@ func runHostCheck(args options.Arguments) (error, bool) {
 			return fmt.Errorf("Error while sending request to SSL Labs API: %w", err), false
 		}
 
-		return nil, false
-	}
-
-	ok = true // By default everything is fine
-
-	if fsutil.CheckPerms("FR", args.Get(0).String()) {
-		hosts, err = readHostList(args.Get(0).String())
+		renderInitError(hosts, err)
 
-		if err != nil {
-			if !options.GetB(OPT_FORMAT) {
-				return err, false
-			}
+		exitCode = EC_API
 
-			return nil, false
-		}
-	} else {
-		hosts = args.Strings()
+		return nil, false
 	}
 
 	var grade string
 	var expiredSoon bool
+	var checkErr *CheckError
 	var checksInfo []*HostCheckInfo
 	var checkInfo *HostCheckInfo
+	var unchecked []string
+
+	if isStreamOutput() {
+		emitQueuedEvents(hosts)
+	}
+
+	trackInterrupts()
+
+	if runTimeout > 0 {
+		runDeadline = time.Now().Add(runTimeout)
+	}
 
 	for _, host := range hosts {
 		switch {
-		case options.GetB(OPT_QUIET):
-			grade, expiredSoon, _ = quietCheck(host)
+		case isInterrupted():
+			grade, expiredSoon, checkInfo = "Err", false, getCancelledCheckInfo(host)
+			checksInfo = append(checksInfo, checkInfo)
+			checkErr = checkInfo.Error
+
+			if isStreamOutput() {
+				emitResultEvent(checkInfo)
+			}
 		case options.GetB(OPT_FORMAT):
-			grade, expiredSoon, checkInfo = quietCheck(host)
+			// Format is checked before quiet mode, so report and stream of
+			// events are always complete
+			grade, expiredSoon, checkInfo = quietCheck(host, getAnalyzeParams())
 			checksInfo = append(checksInfo, checkInfo)
+			checkErr = checkInfo.Error
+
+			if isStreamOutput() {
+				emitResultEvent(checkInfo)
+			}
+		case options.GetB(OPT_QUIET):
+			grade, expiredSoon, checkInfo = quietCheck(host, getAnalyzeParams())
+			checkErr = checkInfo.Error
+		case options.GetB(OPT_TUI), options.GetB(OPT_INVENTORY), options.GetB(OPT_SAN_AUDIT):
+			fmtc.TPrintf("{*}%s{!} {s-}→{!} {s}Checking…{!}", host)
+			grade, expiredSoon, checkInfo = quietCheck(host, getAnalyzeParams())
+			checksInfo = append(checksInfo, checkInfo)
+			checkErr = checkInfo.Error
+			fmtc.TPrintf("")
 		default:
-			grade, expiredSoon = check(host)
+			grade, expiredSoon, checkErr = check(host)
 			fmtc.NewLine()
 		}
 
-		switch {
-		case options.GetB(OPT_PERFECT) && grade != "A+",
-			strutil.Head(grade, 1) != "A",
-			expiredSoon:
-			ok = false
+		if checkErr != nil && checkErr.Phase == PHASE_CANCELLED {
+			unchecked = append(unchecked, host)
+		}
+
+		exitCode |= getExitCode(grade, expiredSoon, checkErr)
+
+		if hasPolicyViolations(host) {
+			exitCode |= EC_POLICY
+		}
+	}
+
+	if len(unchecked) != 0 {
+		exitCode = EC_CANCELLED
+	}
+
+	ok = exitCode == EC_OK
+
+	if isInterrupted() {
+		saveResumeState(unchecked)
+	}
+
+	switch {
+	case options.GetB(OPT_INVENTORY):
+		renderInventory(checksInfo)
+		printCancelledHosts(checksInfo)
+	case options.GetB(OPT_SAN_AUDIT):
+		renderSANAudit(checksInfo)
+		printCancelledHosts(checksInfo)
+	case options.GetS(OPT_FORMAT) != "":
+		renderReport(appendSkippedHosts(checksInfo, noHTTPS))
+	}
+
+	if options.GetB(OPT_TUI) && !isInterrupted() {
+		// Global deadline is not applied to checks started from UI
+		runDeadline = time.Time{}
+
+		err = runTUI(checksInfo)
+
+		if err != nil {
+			return err, false
 		}
 	}
 
-	if options.Has(OPT_FORMAT) {
-		renderReport(checksInfo)
+	printNoHTTPSHosts(noHTTPS)
+
+	if options.GetS(OPT_CALENDAR) != "" {
+		err = saveCalendar(options.GetS(OPT_CALENDAR))
+
+		if err != nil {
+			return err, false
+		}
 	}
 
 	if options.GetB(OPT_NOTIFY) {
@@ -383,39 +661,70 @@ func runHostCheck(args options.Arguments) (error, bool) {
 }
 
 // check check some host
-func check(host string) (string, bool) {
+func check(host string) (string, bool, *CheckError) {
 	var err error
 	var info *sslscan.AnalyzeInfo
 
 	showServerMessage()
 
-	params := sslscan.AnalyzeParams{
-		Public:         options.GetB(OPT_PUBLIC),
-		StartNew:       options.GetB(OPT_AVOID_CACHE),
-		FromCache:      !options.GetB(OPT_AVOID_CACHE),
-		IgnoreMismatch: options.GetB(OPT_IGNORE_MISMATCH),
+	params := getAnalyzeParams()
+
+	deadline := getHostDeadline()
+
+	if isDeadlineExceeded(deadline) {
+		checkErr := newTimeoutError()
+		fmtc.Printfn("{*}%s{!} {s-}→{!} {r}%s{!}", host, checkErr.Message)
+		return "Err", false, checkErr
 	}
 
 	fmtc.TPrintf("{*}%s{!} {s-}→{!} {s}Preparing for tests…{!}", host)
 
-	ap, err := api.Analyze(host, params)
+	rt := newRetrier(deadline)
+	rt.OnRetry = func(err error, attempt int, delay time.Duration) {
+		fmtc.TPrintf(
+			"{*}%s{!} {s-}→{!} {y}%v{!} {s-}(retry %d/%d in %s){!}", host, err,
+			attempt, rt.Attempts-1, timeutil.ShortDuration(delay),
+		)
+	}
+
+	var ap *sslscan.AnalyzeProgress
+
+	err = rt.Do(func() error {
+		ap, err = api.Analyze(host, params)
+		return err
+	})
 
 	if err != nil {
 		fmtc.TPrintf("{*}%s{!} {s-}→{!} {r}%v{!}\n", host, err)
-		return "T", false
+		return "Err", false, newRequestError(PHASE_ANALYZE, err)
 	}
 
 	for {
-		info, err = ap.Info(false, params.FromCache)
+		if isDeadlineExceeded(deadline) {
+			checkErr := newTimeoutError()
+			fmtc.TPrintf("{*}%s{!} {s-}→{!} {r}%s{!}\n", host, checkErr.Message)
+			return "Err", false, checkErr
+		}
+
+		if isCancelled() {
+			checkErr := newCancelledError()
+			fmtc.TPrintf("{*}%s{!} {s-}→{!} {y}%s{!}\n", host, checkErr.Message)
+			return "Err", false, checkErr
+		}
+
+		err = rt.Do(func() error {
+			info, err = ap.Info(false, params.FromCache)
+			return err
+		})
 
 		if err != nil {
 			fmtc.TPrintf("{*}%s{!} {s-}→{!} {r}%v{!}\n", host, err)
-			return "Err", false
+			return "Err", false, newRequestError(PHASE_INFO, err)
 		}
 
 		if info.Status == sslscan.STATUS_ERROR {
 			fmtc.TPrintf("{*}%s{!} {s-}→{!} {r}%s{!}\n", host, info.StatusMessage)
-			return "Err", false
+			return "Err", false, newAssessmentError(info.StatusMessage)
 		} else if info.Status == sslscan.STATUS_READY {
 			break
 		}
@@ -435,12 +744,47 @@ func check(host string) (string, bool) {
 		}
 	}
 
-	expiryMessage := getExpiryMessage(ap, maxLeftToExpiry)
+	var fullInfo *sslscan.AnalyzeInfo
+	var checkErr *CheckError
+
+	if needFullInfo() || options.GetB(OPT_DETAILED) {
+		err = rt.Do(func() error {
+			fullInfo, err = ap.Info(true, true)
+			return err
+		})
+
+		if err != nil {
+			fullInfo, checkErr = nil, newRequestError(PHASE_DETAILS, err)
+		} else if options.GetS(OPT_SAVE_REPORTS) != "" {
+			saveAnalyzeReport(options.GetS(OPT_SAVE_REPORTS), fullInfo)
+		}
+	}
+
+	expiryMessage := getExpiryMessage(fullInfo, maxLeftToExpiry)
+	retryMessage := getRetryMessage(rt.Count)
+
+	if options.GetS(OPT_CALENDAR) != "" {
+		collectExpiryEvents(host, fullInfo)
+	}
 
 	if len(info.Endpoints) == 1 {
-		fmtc.TPrintf("{*}%s{!} {s-}→{!} "+getColoredGrade(info.Endpoints[0].Grade)+expiryMessage+"\n", host)
+		fmtc.TPrintf("{*}%s{!} {s-}→{!} "+getColoredGrade(info.Endpoints[0].Grade)+expiryMessage+retryMessage+"\n", host)
 	} else {
-		fmtc.TPrintf("{*}%s{!} {s-}→{!} "+getColoredGrades(info.Endpoints)+expiryMessage+"\n", host)
+		fmtc.TPrintf("{*}%s{!} {s-}→{!} "+getColoredGrades(info.Endpoints)+expiryMessage+retryMessage+"\n", host)
+	}
+
+	if checkErr != nil {
+		fmtc.Printfn("  {s-}└{!} {r}Can't fetch full assessment info: %s{!}", checkErr.Message)
+	}
+
+	if policy != nil {
+		printPolicyViolations(host, fullInfo)
+	}
+
+	lowestGrade, _ := getGrades(info.Endpoints)
+
+	if checkErr != nil {
+		return lowestGrade, expiryMessage != "", checkErr
 	}
 
 	if options.GetB(OPT_DETAILED) {
@@ -450,12 +794,10 @@ func check(host string) (string, bool) {
 			}
 		}
 
-		printDetailedInfo(ap, true)
+		printDetailedInfo(fullInfo)
 	}
 
-	lowestGrade, _ := getGrades(info.Endpoints)
-
-	return lowestGrade, expiryMessage != ""
+	return lowestGrade, expiryMessage != "", nil
 }
 
 // showServerMessage show message from SSL Labs API
@@ -487,55 +829,129 @@ func showServerMessage() {
 }
 
 // quietCheck check some host without any output to console
-func quietCheck(host string) (string, bool, *HostCheckInfo) {
+func quietCheck(host string, params sslscan.AnalyzeParams) (string, bool, *HostCheckInfo) {
+	grade, expiredSoon, checkInfo := runQuietCheck(host, params, isCancelled)
+	applyCheckResult(checkInfo)
+	return grade, expiredSoon, checkInfo
+}
+
+// runQuietCheck checks host without any output to console
+//
+// Check doesn't change global state, so it can be safely started in background,
+// results must be applied using applyCheckResult. Given function is used for
+// checking if check must be cancelled.
+func runQuietCheck(host string, params sslscan.AnalyzeParams, cancelled func() bool) (string, bool, *HostCheckInfo) {
 	var err error
 	var info *sslscan.AnalyzeInfo
 
 	var checkInfo = &HostCheckInfo{
 		Host:            host,
-		LowestGrade:     "T",
-		HighestGrade:    "T",
+		LowestGrade:     "Err",
+		HighestGrade:    "Err",
 		LowestGradeNum:  0.0,
 		HighestGradeNum: 0.0,
 		Endpoints:       make([]*EndpointCheckInfo, 0),
+		Tags:            hostTags[host],
 	}
 
-	params := sslscan.AnalyzeParams{
-		Public:         options.GetB(OPT_PUBLIC),
-		StartNew:       options.GetB(OPT_AVOID_CACHE),
-		FromCache:      !options.GetB(OPT_AVOID_CACHE),
-		IgnoreMismatch: options.GetB(OPT_IGNORE_MISMATCH),
+	deadline := getHostDeadline()
+
+	if isDeadlineExceeded(deadline) {
+		checkInfo.Error = newTimeoutError()
+		return "Err", false, checkInfo
 	}
 
-	ap, err := api.Analyze(host, params)
+	rt := newRetrier(deadline)
+	rt.IsCancelled = cancelled
+
+	if isStreamOutput() {
+		rt.OnRetry = func(err error, attempt int, delay time.Duration) {
+			emitRetryEvent(host, err, attempt, delay)
+		}
+	}
+
+	defer func() { checkInfo.Retries = rt.Count }()
+
+	var ap *sslscan.AnalyzeProgress
+
+	err = rt.Do(func() error {
+		ap, err = api.Analyze(host, params)
+		return err
+	})
 
 	if err != nil {
+		checkInfo.Error = newRequestError(PHASE_ANALYZE, err)
 		return "Err", false, checkInfo
 	}
 
+	var lastMessage string
+
 	for {
-		info, err = ap.Info(false, params.FromCache)
+		if isDeadlineExceeded(deadline) {
+			checkInfo.Error = newTimeoutError()
+			return "Err", false, checkInfo
+		}
+
+		if cancelled() {
+			checkInfo.Error = newCancelledError()
+			return "Err", false, checkInfo
+		}
+
+		err = rt.Do(func() error {
+			info, err = ap.Info(false, params.FromCache)
+			return err
+		})
 
 		if err != nil {
+			checkInfo.Error = newRequestError(PHASE_INFO, err)
 			return "Err", false, checkInfo
 		}
 
 		if info.Status == sslscan.STATUS_ERROR {
+			checkInfo.Error = newAssessmentError(info.StatusMessage)
 			return "Err", false, checkInfo
 		} else if info.Status == sslscan.STATUS_READY {
 			break
 		}
 
+		if isStreamOutput() && len(info.Endpoints) != 0 {
+			message := getStatusInProgress(info.Endpoints)
+
+			if message != "" && message != lastMessage {
+				emitProgressEvent(host, message)
+				lastMessage = message
+			}
+		}
+
 		time.Sleep(time.Second)
 	}
 
 	var expiredSoon bool
 
+	appendEndpointsInfo(checkInfo, info.Endpoints)
+
+	if needFullInfo() {
+		err = rt.Do(func() error {
+			checkInfo.info, err = ap.Info(true, true)
+			return err
+		})
+
+		if err != nil {
+			checkInfo.info = nil
+			checkInfo.Error = newRequestError(PHASE_DETAILS, err)
+		}
+
+		appendSecurityHeaders(checkInfo)
+		appendPreloadReadiness(checkInfo)
+		appendSuitesOrder(checkInfo)
+		checkInfo.Violations = checkPolicy(checkInfo.info)
+	}
+
 	if maxLeftToExpiry > 0 {
-		expiredSoon = getExpiryMessage(ap, maxLeftToExpiry) != ""
+		expiredSoon = appendExpiringCerts(checkInfo, maxLeftToExpiry)
 	}
 
-	appendEndpointsInfo(checkInfo, info.Endpoints)
+	checkInfo.expiredSoon = expiredSoon
 
 	lowestGrade, highestGrade := getGrades(info.Endpoints)
 
@@ -547,6 +963,99 @@ func quietCheck(host string) (string, bool, *HostCheckInfo) {
 	return lowestGrade, expiredSoon, checkInfo
 }
 
+// applyCheckResult applies side effects of finished check: saves full
+// assessment info, records policy violations and collects expiry events
+func applyCheckResult(checkInfo *HostCheckInfo) {
+	if checkInfo.info != nil && options.GetS(OPT_SAVE_REPORTS) != "" {
+		saveAnalyzeReport(options.GetS(OPT_SAVE_REPORTS), checkInfo.info)
+	}
+
+	if policy != nil {
+		recordPolicyViolations(checkInfo.Host, checkInfo.Violations)
+	}
+
+	if options.GetS(OPT_CALENDAR) != "" {
+		collectExpiryEvents(checkInfo.Host, checkInfo.info)
+	}
+}
+
+// getAnalyzeParams returns assessment parameters based on options
+func getAnalyzeParams() sslscan.AnalyzeParams {
+	return sslscan.AnalyzeParams{
+		Public:         options.GetB(OPT_PUBLIC),
+		StartNew:       options.GetB(OPT_AVOID_CACHE),
+		FromCache:      !options.GetB(OPT_AVOID_CACHE),
+		IgnoreMismatch: options.GetB(OPT_IGNORE_MISMATCH),
+	}
+}
+
+// getHostDeadline returns time after which host check must be stopped
+func getHostDeadline() time.Time {
+	var deadline time.Time
+
+	if hostTimeout > 0 {
+		deadline = time.Now().Add(hostTimeout)
+	}
+
+	return getEarliestTime(deadline, runDeadline)
+}
+
+// isDeadlineExceeded returns true if given deadline is exceeded
+func isDeadlineExceeded(deadline time.Time) bool {
+	return !deadline.IsZero() && time.Now().After(deadline)
+}
+
+// getEarliestTime returns earliest non-zero time
+func getEarliestTime(t1, t2 time.Time) time.Time {
+	switch {
+	case t1.IsZero():
+		return t2
+	case t2.IsZero(), t1.Before(t2):
+		return t1
+	}
+
+	return t2
+}
+
+// needFullInfo returns true if output requires full assessment info
+func needFullInfo() bool {
+	switch options.GetS(OPT_FORMAT) {
+	case FORMAT_HTML, FORMAT_MD, FORMAT_CSV, FORMAT_TSV,
+		FORMAT_JSON, FORMAT_YAML, FORMAT_XML, FORMAT_NDJSON:
+		return true
+	}
+
+	return options.GetB(OPT_TUI) || options.GetB(OPT_INVENTORY) ||
+		options.GetB(OPT_SAN_AUDIT) || options.GetS(OPT_CALENDAR) != "" ||
+		options.GetS(OPT_SAVE_REPORTS) != "" || maxLeftToExpiry > 0 || policy != nil
+}
+
+// renderInitError renders report with API initialization error for all hosts
+func renderInitError(hosts []string, err error) {
+	var checksInfo []*HostCheckInfo
+
+	for _, host := range hosts {
+		checksInfo = append(checksInfo, &HostCheckInfo{
+			Host:         host,
+			LowestGrade:  "Err",
+			HighestGrade: "Err",
+			Endpoints:    make([]*EndpointCheckInfo, 0),
+			Error:        newRequestError(PHASE_INIT, err),
+			Tags:         hostTags[host],
+		})
+	}
+
+	if isStreamOutput() {
+		for _, checkInfo := range checksInfo {
+			emitResultEvent(checkInfo)
+		}
+
+		return
+	}
+
+	renderReport(checksInfo)
+}
+
 // renderReport renders report in different formats
 func renderReport(checksInfo []*HostCheckInfo) {
 	switch options.GetS(OPT_FORMAT) {
@@ -558,11 +1067,75 @@ func renderReport(checksInfo []*HostCheckInfo) {
 		encodeAsXML(checksInfo)
 	case FORMAT_YAML:
 		encodeAsYAML(checksInfo)
+	case FORMAT_HTML:
+		encodeAsHTML(checksInfo)
+	case FORMAT_MD:
+		encodeAsMarkdown(os.Stdout, checksInfo)
+	case FORMAT_CSV:
+		encodeAsCSV(checksInfo, ',')
+	case FORMAT_TSV:
+		encodeAsCSV(checksInfo, '\t')
+	case FORMAT_NDJSON:
+		// Results already printed while checking
 	default:
 		os.Exit(1)
 	}
 }
 
+// validateReportFormat checks if report with given name can be rendered in
+// format defined by user
+func validateReportFormat(name string) error {
+	format := options.GetS(OPT_FORMAT)
+
+	if format != "" && !slices.Contains(reportFormats, format) {
+		return fmt.Errorf(
+			"%s can't be rendered in %s format (supported formats: %s)",
+			name, format, strings.Join(reportFormats, ", "),
+		)
+	}
+
+	return nil
+}
+
+// getExitCode returns exit code bits for check result
+func getExitCode(grade string, expiredSoon bool, checkErr *CheckError) int {
+	var result int
+
+	if checkErr != nil && checkErr.Phase == PHASE_CANCELLED {
+		return EC_CANCELLED
+	}
+
+	// Check can return grade with error if only fetching of full info failed,
+	// so grade and error are checked separately
+	if checkErr != nil {
+		switch {
+		case checkErr.Phase == PHASE_TIMEOUT:
+			result |= EC_TIMEOUT
+		case checkErr.Phase == PHASE_INIT, checkErr.Phase == PHASE_DETAILS, checkErr.Retryable:
+			result |= EC_API
+		default:
+			result |= EC_ASSESSMENT
+		}
+	}
+
+	switch {
+	case grade == "Err", grade == "":
+		if checkErr == nil {
+			result |= EC_ASSESSMENT
+		}
+	case strutil.Head(grade, 1) != "A":
+		result |= EC_GRADE
+	case options.GetB(OPT_PERFECT) && grade != "A+":
+		result |= EC_POLICY
+	}
+
+	if expiredSoon {
+		result |= EC_EXPIRY
+	}
+
+	return result
+}
+
 // getColoredGrade return grade with color tags
 func getColoredGrade(grade string) string {
 	switch grade {
@@ -619,6 +1192,45 @@ func getGrades(endpoints []*sslscan.EndpointInfo) (string, string) {
 	return gradesN[lowest], gradesN[highest]
 }
 
+// getPolicyFailures returns list of failed checks for host
+func getPolicyFailures(checkInfo *HostCheckInfo) []string {
+	var result []string
+
+	grade := checkInfo.LowestGrade
+
+	switch {
+	case checkInfo.Error != nil:
+		result = append(result, "Assessment failed: "+checkInfo.Error.Error())
+	case grade == "Err":
+		result = append(result, "Assessment failed")
+	case options.GetB(OPT_PERFECT) && grade != "A+":
+		result = append(result, "Grade is lower than A+")
+	case strutil.Head(grade, 1) != "A":
+		result = append(result, "Grade is lower than A")
+	}
+
+	if checkInfo.expiredSoon {
+		expiring := make(map[string][]*ExpiringCert)
+
+		for _, endpoint := range checkInfo.Endpoints {
+			if len(endpoint.ExpiringCerts) != 0 {
+				expiring[endpoint.IPAddress] = endpoint.ExpiringCerts
+			}
+		}
+
+		result = append(result, fmt.Sprintf(
+			"Certificate expires in less than %s: %s", options.GetS(OPT_MAX_LEFT),
+			formatExpiringCerts(expiring, len(checkInfo.Endpoints)),
+		))
+	}
+
+	for _, violation := range checkInfo.Violations {
+		result = append(result, "Policy violation: "+violation)
+	}
+
+	return result
+}
+
 // getStatusInProgress return status message from any in-progress endpoint
 func getStatusInProgress(endpoints []*sslscan.EndpointInfo) string {
 	if len(endpoints) == 1 {
@@ -638,31 +1250,6 @@ func getStatusInProgress(endpoints []*sslscan.EndpointInfo) string {
 	return ""
 }
 
-// readHostList reads file with hosts
-func readHostList(file string) ([]string, error) {
-	var result []string
-
-	listData, err := os.ReadFile(file)
-
-	if err != nil {
-		return nil, err
-	}
-
-	list := strings.Split(string(listData), "\n")
-
-	for _, host := range list {
-		if host != "" {
-			result = append(result, strings.TrimRight(host, " "))
-		}
-	}
-
-	if len(result) == 0 {
-		return result, errors.New("File with hosts is empty")
-	}
-
-	return result, nil
-}
-
 // appendEndpointsInfo append endpoint check result to struct with info about all checks for host
 func appendEndpointsInfo(checkInfo *HostCheckInfo, endpoints []*sslscan.EndpointInfo) {
 	for _, endpoint := range endpoints {
@@ -741,8 +1328,11 @@ func genUsage() *usage.Info {
 
 	info.AppNameColorTag = colorTagApp
 
+	info.AddCommand(CMD_COMPARE, "Compare TLS configuration of two hosts or saved reports", "host-a", "host-b")
+
 	info.AddOption(OPT_EMAIL, "User account email {r}(required){!}", "email")
-	info.AddOption(OPT_FORMAT, "Output result in different formats {s-}(text/json/yaml/xml){!}", "format")
+	info.AddOption(OPT_FORMAT, "Output result in different formats {s-}(text/json/ndjson/yaml/xml/html/markdown/csv/tsv){!}", "format")
+	info.AddOption(OPT_COLUMNS, "Comma-separated list of columns for CSV/TSV output", "columns")
 	info.AddOption(OPT_DETAILED, "Show detailed info for each endpoint")
 	info.AddOption(OPT_IGNORE_MISMATCH, "Proceed with assessments on certificate mismatch")
 	info.AddOption(OPT_AVOID_CACHE, "Disable cache usage")
@@ -752,6 +1342,27 @@ func genUsage() *usage.Info {
 	info.AddOption(OPT_NOTIFY, "Notify when check is done")
 	info.AddOption(OPT_QUIET, "Don't show any output")
 	info.AddOption(OPT_PAGER, "Use pager for long output")
+	info.AddOption(OPT_TUI, "Browse results in interactive terminal UI")
+	info.AddOption(OPT_PROFILE, "Use named profile from configuration file {s-}(flags enabled in configuration can be disabled only by profile){!}", "name")
+	info.AddOption(OPT_IMPORT, "Import hosts from configuration files {s-}(k8s/nginx/apache/haproxy/caddy/zone/terraform){!}", "type")
+	info.AddOption(OPT_INVENTORY, "Show inventory of all certificates served by checked hosts")
+	info.AddOption(OPT_SAN_AUDIT, "Show how checked hosts are covered by certificate names")
+	info.AddOption(OPT_CALENDAR, "Save certificates expiry dates to file in iCalendar format", "file")
+	info.AddOption(OPT_SAVE_REPORTS, "Save full assessment info for every host to directory {s-}(can be used by compare command){!}", "dir")
+	info.AddOption(OPT_ALARMS, "Comma-separated list of reminders before expiry {s-}(num + d/h, default: 30d,7d){!}", "durations")
+	info.AddOption(OPT_ALLOWED_ISSUERS, "Comma-separated list of allowed issuers {s-}(subject pattern or pin-sha256:pin){!}", "issuers")
+	info.AddOption(OPT_REQUIRE_CT, "Require Certificate Transparency info (SCT)")
+	info.AddOption(OPT_REQUIRE_CAA, "Require DNS CAA records which allow certificate issuer")
+	info.AddOption(OPT_MAX_VALIDITY, "Maximum certificate validity period {s-}(num + d/w/m/y){!}", "duration")
+	info.AddOption(OPT_REQUIRE_HEADERS, "Comma-separated list of required security headers {s-}(hsts/csp/xcto/referrer/cookies){!}", "headers")
+	info.AddOption(OPT_BANNED_KEYS, "Comma-separated list of banned keys {s-}(alg or alg-maxsize, RSA-1024 bans RSA keys up to 1024 bits){!}", "keys")
+	info.AddOption(OPT_SUITE_PROFILE, "Reference profile for cipher suites order analysis {s-}(modern/intermediate/old, default: intermediate){!}", "profile")
+	info.AddOption(OPT_FILTER, "Comma-separated list of host name patterns to check {s-}(*.domain.com){!}", "patterns")
+	info.AddOption(OPT_RESUME_FILE, "File for saving list of hosts which weren't checked due to interrupt {s-}(default: sslcli-resume.txt){!}", "file")
+	info.AddOption(OPT_TIMEOUT, "Maximum duration of one host check {s-}(num + s/m/h){!}", "duration")
+	info.AddOption(OPT_DEADLINE, "Maximum duration of all checks {s-}(num + s/m/h){!}", "duration")
+	info.AddOption(OPT_RETRIES, "Number of retries for temporary API errors {s-}(0-10, default: 3){!}", "num")
+	info.AddOption(OPT_RETRY_DEADLINE, "Maximum time for retries of one host {s-}(num + s/m/h){!}", "duration")
 	info.AddOption(OPT_NO_COLOR, "Disable colors in output")
 	info.AddOption(OPT_HELP, "Show this help message")
 	info.AddOption(OPT_VER, "Show version")
@@ -788,6 +1399,121 @@ func genUsage() *usage.Info {
 		"Check all hosts defined in hosts.txt file",
 	)
 
+	info.AddExample(
+		"hosts1.txt hosts2.txt domain.com",
+		"Check all hosts defined in hosts1.txt and hosts2.txt files and domain.com",
+	)
+
+	info.AddRawExample(
+		"cat hosts.txt | sslcli -f json -",
+		"Check all hosts passed through standard input",
+	)
+
+	info.AddRawExample(
+		"kubectl get ingress,certificates -A -o yaml | sslcli -I k8s -f json -",
+		"Check all TLS hosts defined in Kubernetes Ingress and cert-manager Certificate resources",
+	)
+
+	info.AddExample(
+		"-I nginx -f csv /etc/nginx",
+		"Check all TLS-enabled server names defined in nginx configuration files",
+	)
+
+	info.AddRawExample(
+		"terraform show -json | sslcli -I terraform -f json -",
+		"Check all hosts defined in DNS records, load balancers and certificates managed by Terraform",
+	)
+
+	info.AddExample(
+		"-I zone -F '*.domain.com' /var/named/domain.com.zone",
+		"Check all subdomains of domain.com defined in zone file which serve HTTPS",
+	)
+
+	info.AddExample(
+		"-f html hosts.txt > report.html",
+		"Check all hosts defined in hosts.txt file and save results as HTML report",
+	)
+
+	info.AddExample(
+		"-f markdown -d google.com",
+		"Check google.com and print detailed results in Markdown format",
+	)
+
+	info.AddExample(
+		"-f csv --columns host,grade,not-after,days-left hosts.txt",
+		"Check all hosts defined in hosts.txt file and print results as CSV with selected columns",
+	)
+
+	info.AddExample(
+		"-f ndjson hosts.txt",
+		"Check all hosts defined in hosts.txt file and stream results and progress events as NDJSON",
+	)
+
+	info.AddExample(
+		"--inventory -f csv hosts.txt",
+		"Check all hosts defined in hosts.txt file and print inventory of all served certificates as CSV",
+	)
+
+	info.AddExample(
+		"--san-audit hosts.txt",
+		"Check all hosts defined in hosts.txt file and show how they are covered by certificate names",
+	)
+
+	info.AddExample(
+		"-q --calendar expiry.ics --alarms 30d,7d,1d hosts.txt",
+		"Check all hosts defined in hosts.txt file and save certificates expiry dates as calendar with reminders",
+	)
+
+	info.AddExample(
+		"--allowed-issuers \"*Let's Encrypt*\" --require-ct --require-caa --max-validity 90d hosts.txt",
+		"Check all hosts defined in hosts.txt file and fail if any certificate violates issuance policy",
+	)
+
+	info.AddExample(
+		"--require-headers hsts,csp,cookies -f json hosts.txt",
+		"Check all hosts defined in hosts.txt file and fail if required security headers are missing or weak",
+	)
+
+	info.AddExample(
+		"-d --suite-profile old google.com",
+		"Check google.com, show detailed info and compare cipher suites order with Mozilla old profile",
+	)
+
+	info.AddExample(
+		"-T hosts.txt",
+		"Check all hosts defined in hosts.txt file and browse results in terminal UI",
+	)
+
+	info.AddExample(
+		"-f json -t 10m --deadline 1h hosts.txt",
+		"Check all hosts defined in hosts.txt file with 10 minutes limit per host and 1 hour for all checks",
+	)
+
+	info.AddExample(
+		"-R 5 --retry-deadline 10m hosts.txt",
+		"Check all hosts defined in hosts.txt file with up to 5 retries for each request within 10 minutes",
+	)
+
+	info.AddExample(
+		"--profile ci hosts.txt",
+		"Check all hosts defined in hosts.txt file using options from profile \"ci\"",
+	)
+
+	info.AddExample(
+		"compare staging.domain.com domain.com",
+		"Compare TLS configuration of staging.domain.com and domain.com",
+	)
+
+	info.AddExample(
+		"--save-reports reports domain.com",
+		"Check domain.com and save full assessment info to reports/domain.com.json",
+	)
+
+	info.AddExample(
+		"compare reports/domain.com.json domain.com",
+		"Compare current TLS configuration of domain.com with previously saved report",
+	)
+
 	return info
 }
 
@@ -0,0 +1,559 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/terminal"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CompareInfo contains info about differences between two hosts
+type CompareInfo struct {
+	HostA    string            `json:"hostA"`
+	HostB    string            `json:"hostB"`
+	Sections []*CompareSection `json:"sections"`
+}
+
+// CompareSection contains differences in one category
+type CompareSection struct {
+	Name  string         `json:"name"`
+	Diffs []*CompareDiff `json:"diffs"`
+}
+
+// CompareDiff contains info about one changed property
+type CompareDiff struct {
+	Property string `json:"property"`
+	ValueA   string `json:"valueA"`
+	ValueB   string `json:"valueB"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// compareProp is property used for comparison
+type compareProp struct {
+	Name  string
+	Value string
+}
+
+// compareCategory is category of compared properties
+type compareCategory struct {
+	Name    string
+	Missing string
+	Props   func(info *sslscan.AnalyzeInfo, labels []string) []compareProp
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// compareCategories contains all categories used for comparison
+var compareCategories = []compareCategory{
+	{"General", "—", getGeneralProps},
+	{"Protocols", "No", getProtocolsProps},
+	{"Cipher Suites", "—", getCipherSuitesProps},
+	{"Server Key and Certificate", "—", getCertificateProps},
+	{"Security Headers", "—", getSecurityHeadersProps},
+	{"Vulnerabilities", "No", getVulnerabilitiesProps},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// runCompare compares configuration of two hosts
+func runCompare(args options.Arguments) (error, bool) {
+	if len(args) != 2 {
+		return fmt.Errorf("You must define two hosts or reports to compare"), false
+	}
+
+	format := options.GetS(OPT_FORMAT)
+
+	if format != "" && format != FORMAT_JSON {
+		return fmt.Errorf("Comparison supports only %q format", FORMAT_JSON), false
+	}
+
+	var err error
+
+	api, err = sslscan.NewAPI("SSLCli", VER, email)
+
+	if err != nil {
+		return fmt.Errorf("Error while sending request to SSL Labs API: %w", err), false
+	}
+
+	trackInterrupts()
+
+	if runTimeout > 0 {
+		runDeadline = time.Now().Add(runTimeout)
+	}
+
+	infoA, err := getCompareSource(args.Get(0).String())
+
+	if err != nil {
+		setCompareExitCode(err)
+		return err, false
+	}
+
+	infoB, err := getCompareSource(args.Get(1).String())
+
+	if err != nil {
+		setCompareExitCode(err)
+		return err, false
+	}
+
+	compareInfo := compareHosts(infoA, infoB)
+
+	if format == FORMAT_JSON {
+		encodeCompareAsJSON(compareInfo)
+	} else {
+		printCompareInfo(compareInfo)
+	}
+
+	for _, section := range compareInfo.Sections {
+		if len(section.Diffs) != 0 {
+			exitCode = EC_DIFF
+			return nil, false
+		}
+	}
+
+	return nil, true
+}
+
+// getCompareSource returns full info for host or from saved report
+func getCompareSource(source string) (*sslscan.AnalyzeInfo, error) {
+	if fsutil.CheckPerms("FRS", source) {
+		return readAnalyzeReport(source)
+	}
+
+	return fetchFullInfo(source)
+}
+
+// readAnalyzeReport reads saved SSL Labs API response from file
+func readAnalyzeReport(file string) (*sslscan.AnalyzeInfo, error) {
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read report %s: %w", file, err)
+	}
+
+	info := &sslscan.AnalyzeInfo{}
+	err = json.Unmarshal(data, info)
+
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Can't decode report %s (use --%s option to save full assessment info): %w",
+			file, OPT_SAVE_REPORTS, err,
+		)
+	}
+
+	if info.Host == "" || len(info.Endpoints) == 0 {
+		return nil, fmt.Errorf(
+			"Report %s doesn't contain full assessment info (use --%s option to save it)",
+			file, OPT_SAVE_REPORTS,
+		)
+	}
+
+	return info, nil
+}
+
+// saveAnalyzeReport saves full assessment info to given directory
+func saveAnalyzeReport(dir string, info *sslscan.AnalyzeInfo) {
+	file := filepath.Join(dir, info.Host+".json")
+	data, err := json.MarshalIndent(info, "", "  ")
+
+	if err == nil {
+		err = os.WriteFile(file, data, 0644)
+	}
+
+	if err != nil {
+		terminal.Warn("Can't save report %s: %v", file, err)
+	}
+}
+
+// fetchFullInfo runs assessment for host and returns detailed info
+func fetchFullInfo(host string) (*sslscan.AnalyzeInfo, error) {
+	var err error
+	var info *sslscan.AnalyzeInfo
+
+	params := getAnalyzeParams()
+
+	if !options.GetB(OPT_FORMAT) {
+		fmtc.TPrintf("{*}%s{!} {s-}→{!} {s}Preparing for tests…{!}", host)
+		defer fmtc.TPrintf("")
+	}
+
+	var ap *sslscan.AnalyzeProgress
+
+	deadline := getHostDeadline()
+	rt := newRetrier(deadline)
+
+	err = rt.Do(func() error {
+		ap, err = api.Analyze(host, params)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't check %s: %w", host, newRequestError(PHASE_ANALYZE, err))
+	}
+
+	for {
+		if isDeadlineExceeded(deadline) {
+			return nil, fmt.Errorf("Can't check %s: %w", host, newTimeoutError())
+		}
+
+		if isCancelled() {
+			return nil, fmt.Errorf("Can't check %s: %w", host, newCancelledError())
+		}
+
+		err = rt.Do(func() error {
+			info, err = ap.Info(false, params.FromCache)
+			return err
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't check %s: %w", host, newRequestError(PHASE_INFO, err))
+		}
+
+		if info.Status == sslscan.STATUS_ERROR {
+			return nil, fmt.Errorf("Can't check %s: %w", host, newAssessmentError(info.StatusMessage))
+		} else if info.Status == sslscan.STATUS_READY {
+			break
+		}
+
+		if !options.GetB(OPT_FORMAT) && len(info.Endpoints) != 0 {
+			message := getStatusInProgress(info.Endpoints)
+
+			if message != "" {
+				fmtc.TPrintf("{*}%s{!} {s-}→{!} {s}%s…{!}", host, message)
+			}
+		}
+
+		time.Sleep(time.Second)
+	}
+
+	err = rt.Do(func() error {
+		info, err = ap.Info(true, true)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't fetch full analyze info for %s: %w", host, newRequestError(PHASE_DETAILS, err))
+	}
+
+	return info, nil
+}
+
+// setCompareExitCode sets exit code bits for error of host check
+func setCompareExitCode(err error) {
+	var checkErr *CheckError
+
+	if errors.As(err, &checkErr) {
+		exitCode = getExitCode("Err", false, checkErr)
+	}
+}
+
+// compareHosts compares info about two hosts
+func compareHosts(infoA, infoB *sslscan.AnalyzeInfo) *CompareInfo {
+	result := &CompareInfo{HostA: infoA.Host, HostB: infoB.Host}
+	labelsA, labelsB := getEndpointLabels(infoA, infoB)
+
+	for _, category := range compareCategories {
+		result.Sections = append(result.Sections, &CompareSection{
+			Name: category.Name,
+			Diffs: diffProps(
+				category.Props(infoA, labelsA),
+				category.Props(infoB, labelsB),
+				category.Missing,
+			),
+		})
+	}
+
+	return result
+}
+
+// getEndpointLabels returns labels used for matching endpoints of two hosts
+//
+// Endpoints are matched by IP address if hosts have common addresses (e.g.
+// the same host is compared with saved report), otherwise they are matched by
+// position. Labels are empty if both hosts have only one endpoint.
+func getEndpointLabels(infoA, infoB *sslscan.AnalyzeInfo) ([]string, []string) {
+	labelsA := make([]string, len(infoA.Endpoints))
+	labelsB := make([]string, len(infoB.Endpoints))
+
+	if len(infoA.Endpoints) == 1 && len(infoB.Endpoints) == 1 {
+		return labelsA, labelsB
+	}
+
+	byIP := slices.ContainsFunc(infoA.Endpoints, func(a *sslscan.EndpointInfo) bool {
+		return slices.ContainsFunc(infoB.Endpoints, func(b *sslscan.EndpointInfo) bool {
+			return a.IPAddress == b.IPAddress
+		})
+	})
+
+	for index, endpoint := range infoA.Endpoints {
+		labelsA[index] = getEndpointLabel(endpoint, index, byIP)
+	}
+
+	for index, endpoint := range infoB.Endpoints {
+		labelsB[index] = getEndpointLabel(endpoint, index, byIP)
+	}
+
+	return labelsA, labelsB
+}
+
+// getEndpointLabel returns label for endpoint
+func getEndpointLabel(endpoint *sslscan.EndpointInfo, index int, byIP bool) string {
+	if byIP {
+		return endpoint.IPAddress
+	}
+
+	return fmt.Sprintf("#%d", index+1)
+}
+
+// getEndpointsProps returns properties of all endpoints with details, names
+// of properties are prefixed with endpoint labels
+func getEndpointsProps(
+	info *sslscan.AnalyzeInfo, labels []string,
+	props func(details *sslscan.EndpointDetails) []compareProp,
+) []compareProp {
+	var result []compareProp
+
+	for index, endpoint := range info.Endpoints {
+		if endpoint.Details == nil {
+			continue
+		}
+
+		for _, prop := range props(endpoint.Details) {
+			if labels[index] != "" {
+				prop.Name = labels[index] + " " + prop.Name
+			}
+
+			result = append(result, prop)
+		}
+	}
+
+	return result
+}
+
+// diffProps returns differences between two lists of properties
+func diffProps(propsA, propsB []compareProp, missing string) []*CompareDiff {
+	var result []*CompareDiff
+
+	valuesB := make(map[string]string)
+
+	for _, prop := range propsB {
+		valuesB[prop.Name] = prop.Value
+	}
+
+	seen := make(map[string]bool)
+
+	for _, prop := range propsA {
+		seen[prop.Name] = true
+		valueB, ok := valuesB[prop.Name]
+
+		if !ok {
+			valueB = missing
+		}
+
+		if prop.Value != valueB {
+			result = append(result, &CompareDiff{prop.Name, prop.Value, valueB})
+		}
+	}
+
+	for _, prop := range propsB {
+		if !seen[prop.Name] && prop.Value != missing {
+			result = append(result, &CompareDiff{prop.Name, missing, prop.Value})
+		}
+	}
+
+	return result
+}
+
+// printCompareInfo prints differences between hosts
+func printCompareInfo(compareInfo *CompareInfo) {
+	fmtc.NewLine()
+	fmtc.Printfn(
+		" {c*}%s{!} {s-}↔{!} {m*}%s{!}",
+		compareInfo.HostA, compareInfo.HostB,
+	)
+	fmtc.NewLine()
+
+	for _, section := range compareInfo.Sections {
+		printCategoryHeader(os.Stdout, section.Name)
+
+		if len(section.Diffs) == 0 {
+			fmtc.Println(" {s-}No differences{!}")
+			continue
+		}
+
+		for _, diff := range section.Diffs {
+			fmtc.Printfn(
+				" %-40s {s}|{!} {c}%s{!} {s-}→{!} {m}%s{!}",
+				diff.Property, diff.ValueA, diff.ValueB,
+			)
+		}
+	}
+
+	fmtutil.Separator(true)
+	fmtc.NewLine()
+}
+
+// encodeCompareAsJSON prints differences between hosts in JSON format
+func encodeCompareAsJSON(compareInfo *CompareInfo) {
+	jsonData, err := json.MarshalIndent(compareInfo, "", "  ")
+
+	if err != nil {
+		fmt.Println("{}")
+		os.Exit(1)
+	}
+
+	fmt.Println(string(jsonData))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getGeneralProps returns general properties of assessment
+func getGeneralProps(info *sslscan.AnalyzeInfo, labels []string) []compareProp {
+	lowestGrade, highestGrade := getGrades(info.Endpoints)
+
+	result := []compareProp{
+		{"Lowest grade", lowestGrade},
+		{"Highest grade", highestGrade},
+		{"Endpoints", fmt.Sprintf("%d", len(info.Endpoints))},
+	}
+
+	for index, endpoint := range info.Endpoints {
+		if labels[index] != "" {
+			result = append(result, compareProp{labels[index] + " Grade", endpoint.Grade})
+		}
+	}
+
+	return result
+}
+
+// getProtocolsProps returns supported protocols
+func getProtocolsProps(info *sslscan.AnalyzeInfo, labels []string) []compareProp {
+	return getEndpointsProps(info, labels, func(details *sslscan.EndpointDetails) []compareProp {
+		var result []compareProp
+
+		supportedProtocols := getProtocols(details.Protocols)
+
+		for _, protocol := range protocolList {
+			result = append(result, compareProp{protocol, printBool(supportedProtocols[protocol])})
+		}
+
+		return result
+	})
+}
+
+// getCipherSuitesProps returns supported cipher suites for every protocol
+func getCipherSuitesProps(info *sslscan.AnalyzeInfo, labels []string) []compareProp {
+	return getEndpointsProps(info, labels, func(details *sslscan.EndpointDetails) []compareProp {
+		var result []compareProp
+
+		for _, suites := range details.Suites {
+			protocol := protocolsNames[suites.Protocol]
+
+			result = append(result, compareProp{
+				protocol + " server preference", printBool(suites.Preference),
+			})
+
+			for _, suite := range suites.List {
+				result = append(result, compareProp{
+					protocol + " " + suite.Name, fmt.Sprintf("%d", suite.CipherStrength),
+				})
+			}
+		}
+
+		return result
+	})
+}
+
+// getCertificateProps returns properties of server certificate
+func getCertificateProps(info *sslscan.AnalyzeInfo, labels []string) []compareProp {
+	if len(info.Certs) == 0 {
+		return nil
+	}
+
+	cert := info.Certs[0]
+	trusted := "Yes"
+
+	if cert.Issues != 0 {
+		trusted = "No (" + getCertIssuesDesc(cert.Issues) + ")"
+	}
+
+	return []compareProp{
+		{"Subject", extractSubject(cert.Subject)},
+		{"Fingerprint", cert.SHA256Hash},
+		{"Common names", strings.Join(cert.CommonNames, " ")},
+		{"Alternative names", strings.Join(cert.AltNames, " ")},
+		{"Valid until", timeutil.Format(time.Unix(cert.NotAfter/1000, 0), "%Y/%m/%d %H:%M:%S")},
+		{"Key", fmt.Sprintf("%s %d bits", cert.KeyAlg, cert.KeySize)},
+		{"Issuer", extractSubject(cert.IssuerSubject)},
+		{"Signature algorithm", cert.SigAlg},
+		{"Extended Validation", printBool(cert.ValidationType == "E")},
+		{"Revocation status", getRevocationStatus(cert.RevocationStatus)},
+		{"DNS CAA", printBool(cert.DNSCAA)},
+		{"Trusted", trusted},
+	}
+}
+
+// getSecurityHeadersProps returns info about security headers
+func getSecurityHeadersProps(info *sslscan.AnalyzeInfo, labels []string) []compareProp {
+	return getEndpointsProps(info, labels, func(details *sslscan.EndpointDetails) []compareProp {
+		var result []compareProp
+
+		for _, check := range checkSecurityHeaders(details) {
+			result = append(result, compareProp{
+				check.Name, fmt.Sprintf("%s (%s)", check.Status, check.Message),
+			})
+		}
+
+		hpkp := "No"
+
+		if details.HPKPPolicy != nil && details.HPKPPolicy.Status != "" {
+			hpkp = details.HPKPPolicy.Status
+		}
+
+		result = append(result, compareProp{"Public Key Pinning (HPKP)", hpkp})
+
+		for _, preload := range details.HSTSPreloads {
+			result = append(result, compareProp{
+				"HSTS Preloading (" + preload.Source + ")",
+				printBool(preload.Status == sslscan.HSTS_STATUS_PRESENT),
+			})
+		}
+
+		return result
+	})
+}
+
+// getVulnerabilitiesProps returns vulnerabilities status
+func getVulnerabilitiesProps(info *sslscan.AnalyzeInfo, labels []string) []compareProp {
+	return getEndpointsProps(info, labels, func(details *sslscan.EndpointDetails) []compareProp {
+		var result []compareProp
+
+		for _, vuln := range getVulnerabilities(details) {
+			result = append(result, compareProp{vuln.Name, printBool(vuln.Vulnerable)})
+		}
+
+		return result
+	})
+}
@@ -0,0 +1,260 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/knf"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/support"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	CONFIG_GLOBAL  = "/etc/sslcli.knf"
+	CONFIG_USER    = ".config/sslcli/config.knf"
+	CONFIG_DEFAULT = "default"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// configExcluded contains options which can't be defined in configuration file
+var configExcluded = []string{
+	OPT_PROFILE, OPT_HELP, OPT_VER, OPT_VERB_VER, OPT_COMPLETION,
+	OPT_GENERATE_MAN, OPT_REGISTER, OPT_NAME, OPT_ORG,
+}
+
+// configFiles contains paths to all loaded configuration files
+var configFiles []string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// loadConfig reads configuration files and uses them as defaults for options
+// not defined by user
+func loadConfig() error {
+	config, err := readConfig()
+
+	if err != nil {
+		return err
+	}
+
+	profile := options.GetS(OPT_PROFILE)
+
+	if config == nil {
+		if profile != "" {
+			return fmt.Errorf("Can't use profile %q: configuration file not found", profile)
+		}
+
+		return nil
+	}
+
+	applied := make(map[string]bool)
+
+	if profile != "" {
+		if !config.HasSection(profile) {
+			return fmt.Errorf("Profile %q is not defined in configuration", profile)
+		}
+
+		applyConfigSection(config, profile, applied)
+	}
+
+	applyConfigSection(config, CONFIG_DEFAULT, applied)
+
+	return nil
+}
+
+// readConfig reads and merges global and user configuration files
+func readConfig() (*knf.Config, error) {
+	var result *knf.Config
+
+	for _, file := range getConfigPaths() {
+		if !fsutil.IsExist(file) {
+			continue
+		}
+
+		config, err := knf.Read(file)
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't read configuration file %s: %w", file, err)
+		}
+
+		err = validateConfig(config, file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if result == nil {
+			result = config
+		} else {
+			result.Merge(config)
+		}
+
+		configFiles = append(configFiles, file)
+	}
+
+	return result, nil
+}
+
+// getConfigPaths returns paths to configuration files in order of priority
+func getConfigPaths() []string {
+	result := []string{CONFIG_GLOBAL}
+	homeDir, err := os.UserHomeDir()
+
+	if err == nil {
+		result = append(result, filepath.Join(homeDir, CONFIG_USER))
+	}
+
+	return result
+}
+
+// validateConfig checks configuration file for unknown properties and
+// invalid values
+func validateConfig(config *knf.Config, file string) error {
+	for _, section := range config.Sections() {
+		for _, prop := range config.Props(section) {
+			name := getConfigOption(prop)
+
+			if name == "" {
+				return fmt.Errorf(
+					"Unknown or unsupported option %q in section %q of configuration file %s",
+					prop, section, file,
+				)
+			}
+
+			err := validateConfigValue(name, config.GetS(knf.Q(section, prop)))
+
+			if err != nil {
+				return fmt.Errorf(
+					"Invalid value of option %q in section %q of configuration file %s: %w",
+					prop, section, file, err,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateConfigValue checks value of option from configuration
+func validateConfigValue(name, value string) error {
+	opt := optMap[name]
+
+	switch {
+	case opt.Type == options.INT:
+		v, err := strconv.Atoi(value)
+
+		if err != nil {
+			return fmt.Errorf("Value %q is not a number", value)
+		}
+
+		if (opt.Min != 0 || opt.Max != 0) && (float64(v) < opt.Min || float64(v) > opt.Max) {
+			return fmt.Errorf("Value %d is out of range (must be between %g and %g)", v, opt.Min, opt.Max)
+		}
+	case name == OPT_FORMAT:
+		if !slices.Contains(outputFormats, value) {
+			return fmt.Errorf(
+				"Unsupported format %q (must be %s)",
+				value, strings.Join(outputFormats, ", "),
+			)
+		}
+	}
+
+	return nil
+}
+
+// applyConfigSection sets values of options from given configuration section
+//
+// Boolean options have no negative form, so flag enabled in configuration can't
+// be disabled from command line. Such flag can be disabled only by profile which
+// sets it to false, because profile is applied before default section.
+func applyConfigSection(config *knf.Config, section string, applied map[string]bool) {
+	for _, prop := range config.Props(section) {
+		name := getConfigOption(prop)
+
+		if options.Has(name) || applied[name] || isConfigConflicts(name, applied) {
+			continue
+		}
+
+		propName := knf.Q(section, prop)
+
+		switch optMap[name].Type {
+		case options.BOOL:
+			optMap[name].Value = config.GetB(propName)
+		case options.INT:
+			optMap[name].Value = config.GetI(propName)
+		default:
+			optMap[name].Value = config.GetS(propName)
+		}
+
+		applied[name] = true
+	}
+}
+
+// getConfigOption returns name of option for given configuration property
+func getConfigOption(prop string) string {
+	for name := range optMap {
+		long, _ := options.ParseOptionName(name)
+
+		if long == strings.ToLower(prop) && !slices.Contains(configExcluded, name) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// isConfigConflicts returns true if option conflicts with any option defined
+// by user or already set from configuration
+func isConfigConflicts(name string, applied map[string]bool) bool {
+	isSet := func(opt string) bool {
+		return options.Has(opt) || applied[opt]
+	}
+
+	conflicts, _ := optMap[name].Conflicts.([]string)
+
+	if slices.ContainsFunc(conflicts, isSet) {
+		return true
+	}
+
+	for opt, v := range optMap {
+		conflicts, _ := v.Conflicts.([]string)
+
+		if slices.Contains(conflicts, name) && isSet(opt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkConfig returns info about loaded configuration for verbose version info
+func checkConfig(err error) support.Check {
+	switch {
+	case err != nil:
+		return support.Check{Status: support.CHECK_ERROR, Title: "Configuration", Message: err.Error()}
+	case len(configFiles) == 0:
+		return support.Check{Status: support.CHECK_SKIP, Title: "Configuration", Message: "No configuration files found"}
+	}
+
+	message := "Loaded " + strings.Join(configFiles, ", ")
+
+	if options.GetS(OPT_PROFILE) != "" {
+		message += fmt.Sprintf(" (profile: %s)", options.GetS(OPT_PROFILE))
+	}
+
+	return support.Check{Status: support.CHECK_OK, Title: "Configuration", Message: message}
+}
@@ -0,0 +1,231 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	COLUMN_HOST            = "host"
+	COLUMN_IP              = "ip"
+	COLUMN_GRADE           = "grade"
+	COLUMN_GRADE_NUM       = "grade-num"
+	COLUMN_SUBJECT         = "subject"
+	COLUMN_ISSUER          = "issuer"
+	COLUMN_NOT_AFTER       = "not-after"
+	COLUMN_DAYS_LEFT       = "days-left"
+	COLUMN_KEY_ALG         = "key-alg"
+	COLUMN_KEY_SIZE        = "key-size"
+	COLUMN_PROTOCOLS       = "protocols"
+	COLUMN_VULNERABILITIES = "vulnerabilities"
+	COLUMN_RETRIES         = "retries"
+	COLUMN_ERROR           = "error"
+	COLUMN_TAGS            = "tags"
+	COLUMN_VIOLATIONS      = "violations"
+	COLUMN_HEADERS         = "security-headers"
+	COLUMN_HSTS_PRELOAD    = "hsts-preload"
+	COLUMN_SUITES_ORDER    = "suites-order"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// csvRow contains info about one endpoint for CSV/TSV output
+type csvRow map[string]string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// csvColumns contains list of all supported columns
+var csvColumns = []string{
+	COLUMN_HOST, COLUMN_IP, COLUMN_GRADE, COLUMN_GRADE_NUM,
+	COLUMN_SUBJECT, COLUMN_ISSUER, COLUMN_NOT_AFTER, COLUMN_DAYS_LEFT,
+	COLUMN_KEY_ALG, COLUMN_KEY_SIZE, COLUMN_PROTOCOLS, COLUMN_VULNERABILITIES,
+	COLUMN_RETRIES, COLUMN_ERROR, COLUMN_TAGS, COLUMN_VIOLATIONS, COLUMN_HEADERS,
+	COLUMN_HSTS_PRELOAD, COLUMN_SUITES_ORDER,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// encodeAsCSV print check info in CSV or TSV format
+func encodeAsCSV(checksInfo []*HostCheckInfo, separator rune) {
+	columns := getCSVColumns()
+
+	w := csv.NewWriter(os.Stdout)
+	w.Comma = separator
+
+	w.Write(columns)
+
+	for _, checkInfo := range checksInfo {
+		for _, row := range getCSVRows(checkInfo) {
+			var record []string
+
+			for _, column := range columns {
+				record = append(record, row[column])
+			}
+
+			w.Write(record)
+		}
+	}
+
+	w.Flush()
+
+	if w.Error() != nil {
+		os.Exit(1)
+	}
+}
+
+// validateCSVColumns checks columns defined by user
+func validateCSVColumns() error {
+	for _, column := range getCSVColumns() {
+		if !slices.Contains(csvColumns, column) {
+			return fmt.Errorf(
+				"Unknown column %q (supported columns: %s)",
+				column, strings.Join(csvColumns, ", "),
+			)
+		}
+	}
+
+	return nil
+}
+
+// getCSVColumns returns list of columns for output
+func getCSVColumns() []string {
+	if options.GetS(OPT_COLUMNS) == "" {
+		return csvColumns
+	}
+
+	var result []string
+
+	for _, column := range strings.Split(options.GetS(OPT_COLUMNS), ",") {
+		column = strings.TrimSpace(strings.ToLower(column))
+
+		if column != "" {
+			result = append(result, column)
+		}
+	}
+
+	return result
+}
+
+// getCSVRows returns rows with info about all host endpoints
+func getCSVRows(checkInfo *HostCheckInfo) []csvRow {
+	if len(checkInfo.Endpoints) == 0 {
+		row := csvRow{
+			COLUMN_HOST:       checkInfo.Host,
+			COLUMN_GRADE:      checkInfo.LowestGrade,
+			COLUMN_GRADE_NUM:  fmt.Sprintf("%.1f", checkInfo.LowestGradeNum),
+			COLUMN_RETRIES:    fmt.Sprintf("%d", checkInfo.Retries),
+			COLUMN_TAGS:       formatTags(checkInfo.Tags),
+			COLUMN_VIOLATIONS: strings.Join(checkInfo.Violations, "; "),
+		}
+
+		if checkInfo.Error != nil {
+			row[COLUMN_ERROR] = checkInfo.Error.Error()
+		}
+
+		return []csvRow{row}
+	}
+
+	var result []csvRow
+
+	for index, endpoint := range checkInfo.Endpoints {
+		row := csvRow{
+			COLUMN_HOST:         checkInfo.Host,
+			COLUMN_IP:           endpoint.IPAddress,
+			COLUMN_GRADE:        endpoint.Grade,
+			COLUMN_GRADE_NUM:    fmt.Sprintf("%.1f", endpoint.GradeNum),
+			COLUMN_RETRIES:      fmt.Sprintf("%d", checkInfo.Retries),
+			COLUMN_TAGS:         formatTags(checkInfo.Tags),
+			COLUMN_VIOLATIONS:   strings.Join(checkInfo.Violations, "; "),
+			COLUMN_HEADERS:      formatHeaderChecks(endpoint.SecurityHeaders),
+			COLUMN_SUITES_ORDER: formatSuitesOrder(endpoint.SuitesOrder),
+		}
+
+		if checkInfo.HSTSPreload != nil {
+			row[COLUMN_HSTS_PRELOAD] = checkInfo.HSTSPreload.Status
+		}
+
+		if checkInfo.info != nil && index < len(checkInfo.info.Endpoints) {
+			appendCSVDetails(row, checkInfo.info, checkInfo.info.Endpoints[index])
+		}
+
+		result = append(result, row)
+	}
+
+	return result
+}
+
+// appendCSVDetails appends certificate and protocol info to row
+func appendCSVDetails(row csvRow, info *sslscan.AnalyzeInfo, endpoint *sslscan.EndpointInfo) {
+	cert := getEndpointCert(info, endpoint)
+
+	if cert != nil {
+		row[COLUMN_SUBJECT] = extractSubject(cert.Subject)
+		row[COLUMN_ISSUER] = extractSubject(cert.IssuerSubject)
+		row[COLUMN_NOT_AFTER] = timeutil.Format(time.Unix(cert.NotAfter/1000, 0), "%Y-%m-%d")
+		row[COLUMN_DAYS_LEFT] = fmt.Sprintf("%d", getValidDays(cert))
+		row[COLUMN_KEY_ALG] = cert.KeyAlg
+		row[COLUMN_KEY_SIZE] = fmt.Sprintf("%d", cert.KeySize)
+	}
+
+	if endpoint.Details == nil {
+		return
+	}
+
+	var protocols, vulns []string
+
+	supportedProtocols := getProtocols(endpoint.Details.Protocols)
+
+	for _, protocol := range protocolList {
+		if supportedProtocols[protocol] {
+			protocols = append(protocols, protocol)
+		}
+	}
+
+	for _, vuln := range getVulnerabilities(endpoint.Details) {
+		if vuln.Vulnerable {
+			vulns = append(vulns, vuln.Name)
+		}
+	}
+
+	row[COLUMN_PROTOCOLS] = strings.Join(protocols, ", ")
+	row[COLUMN_VULNERABILITIES] = strings.Join(vulns, ", ")
+}
+
+// getEndpointCert returns leaf certificate served by endpoint
+func getEndpointCert(info *sslscan.AnalyzeInfo, endpoint *sslscan.EndpointInfo) *sslscan.Cert {
+	if endpoint.Details != nil && len(endpoint.Details.CertChains) != 0 {
+		chain := endpoint.Details.CertChains[0]
+
+		if len(chain.CertIDs) != 0 {
+			cert := findCertByID(info.Certs, chain.CertIDs[0])
+
+			if cert != nil {
+				return cert
+			}
+		}
+	}
+
+	if len(info.Certs) != 0 {
+		return info.Certs[0]
+	}
+
+	return nil
+}
@@ -8,7 +8,10 @@ package cli
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"os"
 	"slices"
 	"strings"
 	"time"
@@ -51,6 +54,27 @@ var weakAlgorithms = map[string]bool{
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// infoSection contains rendered section of detailed info
+type infoSection struct {
+	Title    string
+	Endpoint string
+	Lines    []string
+}
+
+// sectionBuffer is buffer for rendering sections of detailed info, sections
+// have own titles, so category headers are not rendered into it
+type sectionBuffer struct {
+	bytes.Buffer
+}
+
+// vulnInfo contains info about vulnerability status
+type vulnInfo struct {
+	Name       string
+	Vulnerable bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // isInsecureForwardSecrecy is flag for insecure forward secrecy
 var isInsecureForwardSecrecy bool
 
@@ -59,97 +83,157 @@ var isWeakForwardSecrecy bool
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// printDetailedInfo fetches and prints detailed info for all endpoints
-func printDetailedInfo(ap *sslscan.AnalyzeProgress, fromCache bool) {
-	info, err := ap.Info(true, fromCache)
-
-	if err != nil {
-		terminal.Error("\nCan't fetch full analyze info: %v\n", err)
-		return
-	}
-
+// printDetailedInfo prints detailed info for all endpoints
+func printDetailedInfo(info *sslscan.AnalyzeInfo) {
 	if strings.ToUpper(info.Status) != "READY" {
 		terminal.Error("\n%s\n", info.StatusMessage)
 		return
 	}
 
-	printCertificateInfo(info.Certs, info.Endpoints)
+	printCertificateInfo(os.Stdout, info.Certs, info.Endpoints)
 
 	for index, endpoint := range info.Endpoints {
 		fmtc.Printfn("\n{c*} %s {!*}#%d (%s){!}", info.Host, index+1, endpoint.IPAddress)
-		printDetailedEndpointInfo(endpoint, info.Certs)
+		printDetailedEndpointInfo(os.Stdout, info.Host, endpoint, info.Certs)
+	}
+}
+
+// getDetailedSections renders all sections of detailed info
+func getDetailedSections(info *sslscan.AnalyzeInfo) []*infoSection {
+	if info == nil || len(info.Endpoints) == 0 {
+		return nil
+	}
+
+	result := []*infoSection{
+		{
+			Title: "Server Key and Certificate",
+			Lines: renderSection(func(w io.Writer) { printCertificateInfo(w, info.Certs, info.Endpoints) }),
+		},
+	}
+
+	for index, endpoint := range info.Endpoints {
+		if endpoint.Details == nil {
+			continue
+		}
+
+		name := fmt.Sprintf("#%d (%s)", index+1, endpoint.IPAddress)
+
+		isInsecureForwardSecrecy = false
+		isWeakForwardSecrecy = false
+
+		for _, section := range []struct {
+			title  string
+			render func(w io.Writer)
+		}{
+			{"Certification Paths", func(w io.Writer) { printChainInfo(w, endpoint, info.Certs) }},
+			{"Protocols", func(w io.Writer) { printProtocolsInfo(w, endpoint.Details) }},
+			{"Cipher Suites", func(w io.Writer) { printCipherSuitesInfo(w, endpoint.Details) }},
+			{"Cipher Suites Order", func(w io.Writer) { printSuitesOrderInfo(w, endpoint.Details) }},
+			{"Handshake Simulation", func(w io.Writer) { printHandshakeSimulationInfo(w, endpoint.Details) }},
+			{"Protocol Details", func(w io.Writer) { printProtocolDetailsInfo(w, endpoint.Details) }},
+			{"HTTP Requests", func(w io.Writer) { printTransactionsInfo(w, endpoint.Details) }},
+			{"Security Headers", func(w io.Writer) { printSecurityHeadersInfo(w, endpoint.Details) }},
+			{"HSTS Preload", func(w io.Writer) { printHSTSPreloadInfo(w, info.Host, endpoint) }},
+			{"Miscellaneous", func(w io.Writer) { printMiscellaneousInfo(w, endpoint) }},
+		} {
+			result = append(result, &infoSection{
+				Title:    section.title,
+				Endpoint: name,
+				Lines:    renderSection(section.render),
+			})
+		}
 	}
+
+	return result
+}
+
+// renderSection renders section of detailed info and returns it as lines
+func renderSection(render func(w io.Writer)) []string {
+	buf := &sectionBuffer{}
+
+	render(buf)
+
+	data := strings.Trim(buf.String(), "\n")
+
+	if data == "" {
+		return []string{fmtc.Sprint(" {s-}—{!}")}
+	}
+
+	return strings.Split(data, "\n")
 }
 
 // printCertificateInfo prints info about server certificate
-func printCertificateInfo(certs []*sslscan.Cert, endpoints []*sslscan.EndpointInfo) {
-	fmtc.NewLine()
+func printCertificateInfo(w io.Writer, certs []*sslscan.Cert, endpoints []*sslscan.EndpointInfo) {
+	fmtc.Fprintln(w)
 
-	printCategoryHeader("Server Key and Certificate")
+	printCategoryHeader(w, "Server Key and Certificate")
 
 	if len(certs) == 0 {
-		fmtc.Println("\n {r}No valid certificates and keys{!}\n")
-		fmtutil.Separator(true)
+		fmtc.Fprintln(w, "\n {r}No valid certificates and keys{!}\n")
+		printSeparator(w)
 		return
 	}
 
 	cert := certs[0]
 
-	fmtc.Printfn(" %-24s {s}|{!} %s", "Subject", extractSubject(cert.Subject))
-	fmtc.Printfn(" %-24s {s}|{!} {s-}Fingerprint: %s{!}", "", cert.SHA256Hash)
-	fmtc.Printfn(" %-24s {s}|{!} {s-}Pin: %s{!}", "", cert.PINSHA256)
+	fmtc.Fprintfn(w, " %-24s {s}|{!} %s", "Subject", extractSubject(cert.Subject))
+	fmtc.Fprintfn(w, " %-24s {s}|{!} {s-}Fingerprint: %s{!}", "", cert.SHA256Hash)
+	fmtc.Fprintfn(w, " %-24s {s}|{!} {s-}Pin: %s{!}", "", cert.PINSHA256)
 
-	printCertNamesInfo(cert)
-	printCertValidityInfo(cert)
+	printCertNamesInfo(w, cert)
+	printCertValidityInfo(w, cert)
 
-	fmtc.Printfn(" %-24s {s}|{!} %s", "Serial number", cert.SerialNumber)
-	fmtc.Printfn(" %-24s {s}|{!} %s %d bits", "Key", cert.KeyAlg, cert.KeySize)
-	fmtc.Printfn(" %-24s {s}|{!} %s", "Weak Key (Debian)", printBool(cert.KeyKnownDebianInsecure))
+	fmtc.Fprintfn(w, " %-24s {s}|{!} %s", "Serial number", cert.SerialNumber)
+	fmtc.Fprintfn(w, " %-24s {s}|{!} %s %d bits", "Key", cert.KeyAlg, cert.KeySize)
+	fmtc.Fprintfn(w, " %-24s {s}|{!} %s", "Weak Key (Debian)", printBool(cert.KeyKnownDebianInsecure))
 
-	printCertIssuerInfo(cert)
-	printCertSignatureInfo(cert)
-	printCertValidationTypeInfo(cert)
-	printCertTransparencyInfo(cert, endpoints)
-	printCertRevocationInfo(cert)
-	printCertDNSCAAInfo(cert)
-	printCertTrustInfo(cert, endpoints)
+	printCertIssuerInfo(w, cert)
+	printCertSignatureInfo(w, cert)
+	printCertValidationTypeInfo(w, cert)
+	printCertTransparencyInfo(w, cert, endpoints)
+	printCertRevocationInfo(w, cert)
+	printCertDNSCAAInfo(w, cert)
+	printCertTrustInfo(w, cert, endpoints)
 
-	fmtutil.Separator(true)
+	printSeparator(w)
 }
 
 // printDetailedEndpointInfo fetches and print detailed info for one endpoint
-func printDetailedEndpointInfo(info *sslscan.EndpointInfo, certs []*sslscan.Cert) {
-	fmtc.NewLine()
+func printDetailedEndpointInfo(w io.Writer, host string, info *sslscan.EndpointInfo, certs []*sslscan.Cert) {
+	fmtc.Fprintln(w)
 
 	isInsecureForwardSecrecy = false
 	isWeakForwardSecrecy = false
 
-	printChainInfo(info, certs)
-	printProtocolsInfo(info.Details)
-	printCipherSuitesInfo(info.Details)
-	printHandshakeSimulationInfo(info.Details)
-	printProtocolDetailsInfo(info.Details)
-	printTransactionsInfo(info.Details)
-	printMiscellaneousInfo(info)
+	printChainInfo(w, info, certs)
+	printProtocolsInfo(w, info.Details)
+	printCipherSuitesInfo(w, info.Details)
+	printSuitesOrderInfo(w, info.Details)
+	printHandshakeSimulationInfo(w, info.Details)
+	printProtocolDetailsInfo(w, info.Details)
+	printTransactionsInfo(w, info.Details)
+	printSecurityHeadersInfo(w, info.Details)
+	printHSTSPreloadInfo(w, host, info)
+	printMiscellaneousInfo(w, info)
 
-	fmtutil.Separator(true)
+	printSeparator(w)
 }
 
 // printChainInfo prints info about certificates in chain
-func printChainInfo(info *sslscan.EndpointInfo, certs []*sslscan.Cert) {
+func printChainInfo(w io.Writer, info *sslscan.EndpointInfo, certs []*sslscan.Cert) {
 	if len(info.Details.CertChains) == 0 {
 		return
 	}
 
-	printCategoryHeader("Certification Paths")
+	printCategoryHeader(w, "Certification Paths")
 
 	chain := info.Details.CertChains[0]
 
-	printChainBasicInfo(chain)
+	printChainBasicInfo(w, chain)
 
 	if len(chain.CertIDs) > 1 {
 		for i := 1; i < len(chain.CertIDs); i++ {
-			fmtutil.Separator(true)
+			printSeparator(w)
 
 			certID := chain.CertIDs[i]
 			cert := findCertByID(certs, certID)
@@ -158,33 +242,33 @@ func printChainInfo(info *sslscan.EndpointInfo, certs []*sslscan.Cert) {
 				continue
 			}
 
-			printChainCertInfo(cert)
+			printChainCertInfo(w, cert)
 		}
 	}
 }
 
 // printProtocolsInfo prints info about supported protocols
-func printProtocolsInfo(details *sslscan.EndpointDetails) {
+func printProtocolsInfo(w io.Writer, details *sslscan.EndpointDetails) {
 	if len(details.Protocols) == 0 {
 		return
 	}
 
-	printCategoryHeader("Protocols")
+	printCategoryHeader(w, "Protocols")
 
 	supportedProtocols := getProtocols(details.Protocols)
 
 	for _, protocol := range protocolList {
-		printProtocolInfo(protocol, supportedProtocols)
+		printProtocolInfo(w, protocol, supportedProtocols)
 	}
 }
 
 // printCipherSuitesInfo prints info about supported cipher suites
-func printCipherSuitesInfo(details *sslscan.EndpointDetails) {
+func printCipherSuitesInfo(w io.Writer, details *sslscan.EndpointDetails) {
 	if details.Suites == nil && details.NoSNISuites == nil {
 		return
 	}
 
-	printCategoryHeader("Cipher Suites")
+	printCategoryHeader(w, "Cipher Suites")
 
 	var allSuites []*sslscan.ProtocolSuites
 
@@ -198,82 +282,82 @@ func printCipherSuitesInfo(details *sslscan.EndpointDetails) {
 		suites := allSuites[i]
 		noSNI := details.NoSNISuites != nil && suites.Protocol == details.NoSNISuites.Protocol
 
-		printProtocolSuitesInfo(suites, noSNI)
+		printProtocolSuitesInfo(w, suites, noSNI)
 
-		fmtutil.Separator(true)
+		printSeparator(w)
 
 		for _, suite := range suites.List {
-			printProtocolSuiteInfo(suite, details.ChaCha20Preference)
+			printProtocolSuiteInfo(w, suite, details.ChaCha20Preference)
 		}
 
 		if i != 0 {
-			fmtutil.Separator(true)
+			printSeparator(w)
 		}
 	}
 }
 
 // printHandshakeSimulationInfo prints info about handshakes simulations
-func printHandshakeSimulationInfo(details *sslscan.EndpointDetails) {
+func printHandshakeSimulationInfo(w io.Writer, details *sslscan.EndpointDetails) {
 	if details.SIMS == nil || len(details.SIMS.Results) == 0 {
 		return
 	}
 
-	printCategoryHeader("Handshake Simulation")
+	printCategoryHeader(w, "Handshake Simulation")
 
 	for _, sim := range details.SIMS.Results {
 		if sim.ErrorCode != 0 {
-			fmtc.Printfn(" %-20s {s}|{!} {r}Fail{!}", sim.Client.Name+" "+sim.Client.Version)
+			fmtc.Fprintfn(w, " %-20s {s}|{!} {r}Fail{!}", sim.Client.Name+" "+sim.Client.Version)
 			continue
 		}
 
-		printSimulationInfo(sim, details.Suites)
+		printSimulationInfo(w, sim, details.Suites)
 	}
 }
 
 // printProtocolDetailsInfo prints endpoint protocol details
-func printProtocolDetailsInfo(details *sslscan.EndpointDetails) {
-	printCategoryHeader("Protocol Details")
-
-	printEndpointRenegotiationInfo(details)
-	printEndpointPoodleStatus(details)
-	printEndpointDrownStatus(details)
-	printEndpointLogjamStatus(details)
-	printEndpointFreakStatus(details)
-	printEndpointFallbackSCSVStatus(details)
-	printEndpointCompressionInfo(details)
-	printEndpointRC4SupportStatus(details)
-	printEndpointHeartbeatStatus(details)
-	printEndpointHeartbleedStatus(details)
-	printEndpointTicketbleedStatus(details)
-	printEndpointOpenSSLCCSStatus(details)
-	printEndpointLuckyMinus20Status(details)
-	printEndpointRobotStatus(details)
-	printEndpointFSStatus(details)
-	printEndpointALPNStatus(details)
-	printEndpointNPNStatus(details)
-	printEndpointSNIStatus(details)
-	printEndpointSessionsInfo(details)
-	printEndpointStaplingInfo(details)
-	printEndpointHSTSInfo(details)
-	printEndpointHPKPInfo(details)
-	printEndpointHandshakeInfo(details)
-	printEndpointTLSInfo(details)
-	printEndpointDHPrimesInfo(details)
-	printEndpointECDHInfo(details)
-	printEndpointNamedGroups(details.NamedGroups)
-	print0RTTStatus(details.ZeroRTTEnabled)
+func printProtocolDetailsInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	printCategoryHeader(w, "Protocol Details")
+
+	printEndpointRenegotiationInfo(w, details)
+	printEndpointPoodleStatus(w, details)
+	printEndpointDrownStatus(w, details)
+	printEndpointLogjamStatus(w, details)
+	printEndpointFreakStatus(w, details)
+	printEndpointFallbackSCSVStatus(w, details)
+	printEndpointCompressionInfo(w, details)
+	printEndpointRC4SupportStatus(w, details)
+	printEndpointHeartbeatStatus(w, details)
+	printEndpointHeartbleedStatus(w, details)
+	printEndpointTicketbleedStatus(w, details)
+	printEndpointOpenSSLCCSStatus(w, details)
+	printEndpointLuckyMinus20Status(w, details)
+	printEndpointRobotStatus(w, details)
+	printEndpointFSStatus(w, details)
+	printEndpointALPNStatus(w, details)
+	printEndpointNPNStatus(w, details)
+	printEndpointSNIStatus(w, details)
+	printEndpointSessionsInfo(w, details)
+	printEndpointStaplingInfo(w, details)
+	printEndpointHSTSInfo(w, details)
+	printEndpointHPKPInfo(w, details)
+	printEndpointHandshakeInfo(w, details)
+	printEndpointTLSInfo(w, details)
+	printEndpointDHPrimesInfo(w, details)
+	printEndpointECDHInfo(w, details)
+	printEndpointNamedGroups(w, details.NamedGroups)
+	print0RTTStatus(w, details.ZeroRTTEnabled)
 }
 
 // printTransactionsInfo prints info about HTTP transactions
-func printTransactionsInfo(details *sslscan.EndpointDetails) {
+func printTransactionsInfo(w io.Writer, details *sslscan.EndpointDetails) {
 	if len(details.HTTPTransactions) == 0 {
 		return
 	}
 
-	printCategoryHeader("HTTP Requests")
+	printCategoryHeader(w, "HTTP Requests")
 
 	for index, transaction := range details.HTTPTransactions {
-		fmtc.Printfn(
+		fmtc.Fprintfn(w,
 			" {s-}%d{!} %s {s}(%s){!}",
 			index+1, transaction.RequestURL, transaction.ResponseLine,
 		)
@@ -281,66 +365,75 @@ func printTransactionsInfo(details *sslscan.EndpointDetails) {
 }
 
 // printMiscellaneousInfo prints miscellaneous info about endpoint
-func printMiscellaneousInfo(info *sslscan.EndpointInfo) {
-	printCategoryHeader("Miscellaneous")
+func printMiscellaneousInfo(w io.Writer, info *sslscan.EndpointInfo) {
+	printCategoryHeader(w, "Miscellaneous")
 
-	printTestInfo(info)
-	printWebServerInfo(info)
+	printTestInfo(w, info)
+	printWebServerInfo(w, info)
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // printCategoryHeader prints category name and separators
-func printCategoryHeader(name string) {
-	fmtutil.Separator(true)
-	fmtc.Printfn(" ▾ {*}%s{!}", strings.ToUpper(name))
-	fmtutil.Separator(true)
+func printCategoryHeader(w io.Writer, name string) {
+	if _, ok := w.(*sectionBuffer); ok {
+		return
+	}
+
+	printSeparator(w)
+	fmtc.Fprintfn(w, " ▾ {*}%s{!}", strings.ToUpper(name))
+	printSeparator(w)
+}
+
+// printSeparator prints tiny separator
+func printSeparator(w io.Writer) {
+	fmtc.Fprintln(w, fmtutil.SeparatorColorTag+strings.Repeat(fmtutil.SeparatorSymbol, fmtutil.SeparatorSize)+"{!}")
 }
 
 // printCertNamesInfo prints common and alternative names from certificate
-func printCertNamesInfo(cert *sslscan.Cert) {
-	fmtc.Printfn(" %-24s {s}|{!} %s", "Common names", strings.Join(cert.CommonNames, " "))
+func printCertNamesInfo(w io.Writer, cert *sslscan.Cert) {
+	fmtc.Fprintfn(w, " %-24s {s}|{!} %s", "Common names", strings.Join(cert.CommonNames, " "))
 
 	if len(cert.AltNames) > 0 {
 		if len(cert.AltNames) > 5 {
-			fmtc.Printf(
+			fmtc.Fprintf(w,
 				" %-24s {s}|{!} %s {s-}(+%d more){!}",
 				"Alternative names",
 				strings.Join(cert.AltNames[:4], " "),
 				len(cert.AltNames)-4,
 			)
 		} else {
-			fmtc.Printf(" %-24s {s}|{!} %s", "Alternative names", strings.Join(cert.AltNames, " "))
+			fmtc.Fprintf(w, " %-24s {s}|{!} %s", "Alternative names", strings.Join(cert.AltNames, " "))
 		}
 
 		if cert.Issues&8 == 8 {
-			fmtc.Println(" {r}MISMATCH{!}")
+			fmtc.Fprintln(w, " {r}MISMATCH{!}")
 		} else {
-			fmtc.NewLine()
+			fmtc.Fprintln(w)
 		}
 	}
 }
 
 // printCertValidityInfo prints certificate validity info
-func printCertValidityInfo(cert *sslscan.Cert) {
+func printCertValidityInfo(w io.Writer, cert *sslscan.Cert) {
 	validFromDate := time.Unix(cert.NotBefore/1000, 0)
 	validUntilDate := time.Unix(cert.NotAfter/1000, 0)
 	validDays := (validUntilDate.Unix() - time.Now().Unix()) / 86400
 
-	fmtc.Printfn(
+	fmtc.Fprintfn(w,
 		" %-24s {s}|{!} %s", "Valid from",
 		timeutil.Format(validFromDate, "%Y/%m/%d %H:%M:%S"),
 	)
 
-	fmtc.Printf(" %-24s {s}|{!} ", "Valid until")
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", "Valid until")
 
 	if time.Now().Unix() >= validUntilDate.Unix() {
-		fmtc.Printfn(
+		fmtc.Fprintfn(w,
 			"{r}%s (EXPIRED){!}",
 			timeutil.Format(validUntilDate, "%Y/%m/%d %H:%M:%S"),
 		)
 	} else {
-		fmtc.Printfn(
+		fmtc.Fprintfn(w,
 			"%s {s-}(expires in %s %s){!}",
 			timeutil.Format(validUntilDate, "%Y/%m/%d %H:%M:%S"),
 			fmtutil.PrettyNum(validDays),
@@ -349,45 +442,45 @@ func printCertValidityInfo(cert *sslscan.Cert) {
 	}
 }
 
-func printCertIssuerInfo(cert *sslscan.Cert) {
-	fmtc.Printf(" %-24s {s}|{!} ", "Issuer")
+func printCertIssuerInfo(w io.Writer, cert *sslscan.Cert) {
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", "Issuer")
 
 	if cert.Issues&64 == 64 {
-		fmtc.Printfn("%s {s-}(Self-signed){!}", extractSubject(cert.IssuerSubject))
+		fmtc.Fprintfn(w, "%s {s-}(Self-signed){!}", extractSubject(cert.IssuerSubject))
 	} else {
-		fmtc.Printfn("%s", extractSubject(cert.IssuerSubject))
+		fmtc.Fprintfn(w, "%s", extractSubject(cert.IssuerSubject))
 
 		if len(cert.CRLURIs) != 0 {
-			fmtc.Printfn(" %-24s {s}|{!} {s-}AIA: %s{!}", "", cert.CRLURIs[0])
+			fmtc.Fprintfn(w, " %-24s {s}|{!} {s-}AIA: %s{!}", "", cert.CRLURIs[0])
 		}
 	}
 }
 
 // printCertSignatureInfo prints certificate signature info
-func printCertSignatureInfo(cert *sslscan.Cert) {
-	fmtc.Printf(" %-24s {s}|{!} ", "Signature algorithm")
+func printCertSignatureInfo(w io.Writer, cert *sslscan.Cert) {
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", "Signature algorithm")
 
 	if weakAlgorithms[cert.SigAlg] {
-		fmtc.Printfn("{y}%s (WEAK){!}", cert.SigAlg)
+		fmtc.Fprintfn(w, "{y}%s (WEAK){!}", cert.SigAlg)
 	} else {
-		fmtc.Printfn("%s", cert.SigAlg)
+		fmtc.Fprintfn(w, "%s", cert.SigAlg)
 	}
 }
 
 // printCertValidationTypeInfo prints certificate validation type
-func printCertValidationTypeInfo(cert *sslscan.Cert) {
-	fmtc.Printf(" %-24s {s}|{!} ", "Extended Validation")
+func printCertValidationTypeInfo(w io.Writer, cert *sslscan.Cert) {
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", "Extended Validation")
 
 	if cert.ValidationType == "E" {
-		fmtc.Println("{g}Yes{!}")
+		fmtc.Fprintln(w, "{g}Yes{!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printCertTransparencyInfo prints certificate transparency info
-func printCertTransparencyInfo(cert *sslscan.Cert, endpoints []*sslscan.EndpointInfo) {
-	fmtc.Printf(" %-24s {s}|{!} ", "Certificate Transparency")
+func printCertTransparencyInfo(w io.Writer, cert *sslscan.Cert, endpoints []*sslscan.EndpointInfo) {
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", "Certificate Transparency")
 
 	for _, endpoint := range endpoints {
 		details := endpoint.Details
@@ -396,177 +489,170 @@ func printCertTransparencyInfo(cert *sslscan.Cert, endpoints []*sslscan.Endpoint
 		case 0:
 			continue
 		case 1:
-			fmtc.Println("{g}Yes{!} {s-}(certificate){!}")
+			fmtc.Fprintln(w, "{g}Yes{!} {s-}(certificate){!}")
 		case 2:
-			fmtc.Println("{g}Yes{!} {s-}(stapled OCSP response){!}")
+			fmtc.Fprintln(w, "{g}Yes{!} {s-}(stapled OCSP response){!}")
 		case 4:
-			fmtc.Println("{g}Yes{!} {s-}(TLS extension){!}")
+			fmtc.Fprintln(w, "{g}Yes{!} {s-}(TLS extension){!}")
 		}
 
 		return
 	}
 
-	fmtc.Println("{y}No{!}")
+	fmtc.Fprintln(w, "{y}No{!}")
 }
 
 // printCertRevocationInfo prints certificate revocation status and info
-func printCertRevocationInfo(cert *sslscan.Cert) {
+func printCertRevocationInfo(w io.Writer, cert *sslscan.Cert) {
 	if cert.RevocationInfo != 0 {
-		fmtc.Printfn(
+		fmtc.Fprintfn(w,
 			" %-24s {s}|{!} %s", "Revocation information",
 			getRevocationInfo(cert.RevocationInfo),
 		)
 
 		if len(cert.CRLURIs) != 0 {
-			fmtc.Printfn(" %-24s {s}|{!} {s-}CRL: %s{!}", "", cert.CRLURIs[0])
+			fmtc.Fprintfn(w, " %-24s {s}|{!} {s-}CRL: %s{!}", "", cert.CRLURIs[0])
 		}
 
 		if len(cert.OCSPURIs) != 0 {
-			fmtc.Printfn(" %-24s {s}|{!} {s-}OCSP: %s{!}", "", cert.OCSPURIs[0])
+			fmtc.Fprintfn(w, " %-24s {s}|{!} {s-}OCSP: %s{!}", "", cert.OCSPURIs[0])
 		}
 	}
 
-	fmtc.Printf(" %-24s {s}|{!} ", "Revocation status")
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", "Revocation status")
 
 	if cert.RevocationStatus&1 == 1 {
-		fmtc.Printfn("{r}%s{!}", getRevocationStatus(cert.RevocationStatus))
+		fmtc.Fprintfn(w, "{r}%s{!}", getRevocationStatus(cert.RevocationStatus))
 	} else {
-		fmtc.Printfn("%s", getRevocationStatus(cert.RevocationStatus))
+		fmtc.Fprintfn(w, "%s", getRevocationStatus(cert.RevocationStatus))
 	}
 }
 
 // printCertDNSCAAInfo prints certificate DNS Certification Authority Authorization
-func printCertDNSCAAInfo(cert *sslscan.Cert) {
-	fmtc.Printf(" %-24s {s}|{!} ", "DNS CAA")
+func printCertDNSCAAInfo(w io.Writer, cert *sslscan.Cert) {
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", "DNS CAA")
 
 	if cert.DNSCAA {
-		fmtc.Println("{g}Yes{!}")
+		fmtc.Fprintln(w, "{g}Yes{!}")
 		if cert.CAAPolicy != nil {
-			fmtc.Printfn(
+			fmtc.Fprintfn(w,
 				" %-24s {s}|{!} {s-}policy host: %s{!}", "",
 				cert.CAAPolicy.PolicyHostname,
 			)
 
 			for _, rec := range cert.CAAPolicy.CAARecords {
-				fmtc.Printfn(
+				fmtc.Fprintfn(w,
 					" %-24s {s}|{!} {s-}%s: %s flags: %d{!}", "",
 					rec.Tag, rec.Value, rec.Flags,
 				)
 			}
 		}
 	} else {
-		fmtc.Println("{y}No{!}")
+		fmtc.Fprintln(w, "{y}No{!}")
 	}
 }
 
 // printCertTrustInfo prints certificate trust status
-func printCertTrustInfo(cert *sslscan.Cert, endpoints []*sslscan.EndpointInfo) {
-	fmtc.Printf(" %-24s {s}|{!} ", "Trusted")
+func printCertTrustInfo(w io.Writer, cert *sslscan.Cert, endpoints []*sslscan.EndpointInfo) {
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", "Trusted")
 
 	trustInfo, isTrusted := getTrustInfo(cert.ID, endpoints)
 
 	if !isTrusted {
-		fmtc.Println("{r}No (NOT TRUSTED){!}")
+		fmtc.Fprintln(w, "{r}No (NOT TRUSTED){!}")
 	} else {
 		if cert.Issues == 0 {
-			fmtc.Println("{g}Yes{!}")
+			fmtc.Fprintln(w, "{g}Yes{!}")
 		} else {
-			fmtc.Printfn("{r}No (%s){!}", getCertIssuesDesc(cert.Issues))
+			fmtc.Fprintfn(w, "{r}No (%s){!}", getCertIssuesDesc(cert.Issues))
 		}
 	}
 
-	fmtc.Printf(" %-24s {s}|{!} ", "")
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", "")
 
 	for _, rootStore := range rootStores {
 		switch trustInfo[rootStore] {
 		case true:
-			fmtc.Printf("{g}%s{!} ", rootStore)
+			fmtc.Fprintf(w, "{g}%s{!} ", rootStore)
 		default:
-			fmtc.Printf("{r}%s{!} ", rootStore)
+			fmtc.Fprintf(w, "{r}%s{!} ", rootStore)
 		}
 	}
 
-	fmtc.NewLine()
+	fmtc.Fprintln(w)
 }
 
 // printChainBasicInfo prints info about provided certificates chain
-func printChainBasicInfo(chain *sslscan.ChainCert) {
-	fmtc.Printfn(" %-24s {s}|{!} %d", "Certificates provided", len(chain.CertIDs))
-	fmtc.Printf(" %-24s {s}|{!} ", "Chain issues")
+func printChainBasicInfo(w io.Writer, chain *sslscan.ChainCert) {
+	fmtc.Fprintfn(w, " %-24s {s}|{!} %d", "Certificates provided", len(chain.CertIDs))
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", "Chain issues")
 
 	if chain.Issues == 0 {
-		fmtc.Println("None")
+		fmtc.Fprintln(w, "None")
 	} else {
-		fmtc.Printfn("{y}%s{!}", getChainIssuesDesc(chain.Issues))
+		fmtc.Fprintfn(w, "{y}%s{!}", getChainIssuesDesc(chain.Issues))
 	}
 }
 
 // printChainCertInfo prints basic info about certificate from chain
-func printChainCertInfo(cert *sslscan.Cert) {
+func printChainCertInfo(w io.Writer, cert *sslscan.Cert) {
 	validUntilDate := time.Unix(cert.NotAfter/1000, 0)
 	validDays := (validUntilDate.Unix() - time.Now().Unix()) / 86400
 
-	fmtc.Printfn(" %-24s {s}|{!} %s", "Subject", extractSubject(cert.Subject))
+	fmtc.Fprintfn(w, " %-24s {s}|{!} %s", "Subject", extractSubject(cert.Subject))
 
-	fmtc.Printfn(" %-24s {s}|{!} {s-}Fingerprint: %s{!}", "", cert.SHA256Hash)
-	fmtc.Printfn(" %-24s {s}|{!} {s-}Pin: %s{!}", "", cert.PINSHA256)
+	fmtc.Fprintfn(w, " %-24s {s}|{!} {s-}Fingerprint: %s{!}", "", cert.SHA256Hash)
+	fmtc.Fprintfn(w, " %-24s {s}|{!} {s-}Pin: %s{!}", "", cert.PINSHA256)
 
-	fmtc.Printfn(
+	fmtc.Fprintfn(w,
 		" %-24s {s}|{!} %s {s-}(expires in %s %s){!}", "Valid until",
 		timeutil.Format(validUntilDate, "%Y/%m/%d %H:%M:%S"),
 		fmtutil.PrettyNum(validDays),
 		pluralize.Pluralize(int(validDays), "day", "days"),
 	)
 
-	fmtc.Printf(" %-24s {s}|{!} ", "Key")
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", "Key")
 
-	if cert.KeyAlg == "RSA" && cert.KeyStrength < 2048 {
-		fmtc.Printfn("{y}%s %d bits (WEAK){!}", cert.KeyAlg, cert.KeySize)
+	if isWeakCertKey(cert) {
+		fmtc.Fprintfn(w, "{y}%s %d bits (WEAK){!}", cert.KeyAlg, cert.KeySize)
 	} else {
-		fmtc.Printfn("%s %d bits", cert.KeyAlg, cert.KeySize)
+		fmtc.Fprintfn(w, "%s %d bits", cert.KeyAlg, cert.KeySize)
 	}
 
-	fmtc.Printfn(" %-24s {s}|{!} %s", "Issuer", extractSubject(cert.IssuerSubject))
+	fmtc.Fprintfn(w, " %-24s {s}|{!} %s", "Issuer", extractSubject(cert.IssuerSubject))
 
-	fmtc.Printf(" %-24s {s}|{!} ", "Signature algorithm")
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", "Signature algorithm")
 
 	if weakAlgorithms[cert.SigAlg] {
-		fmtc.Printfn("{y}%s (WEAK){!}", cert.SigAlg)
+		fmtc.Fprintfn(w, "{y}%s (WEAK){!}", cert.SigAlg)
 	} else {
-		fmtc.Printfn("%s", cert.SigAlg)
+		fmtc.Fprintfn(w, "%s", cert.SigAlg)
 	}
 }
 
 // printProtocolInfo prints info about supported protocol
-func printProtocolInfo(protocol string, supportedProtocols map[string]bool) {
-	fmtc.Printf(" %-24s {s}|{!} ", protocol)
+func printProtocolInfo(w io.Writer, protocol string, supportedProtocols map[string]bool) {
+	fmtc.Fprintf(w, " %-24s {s}|{!} ", protocol)
+
+	supported := supportedProtocols[protocol]
+	insecure, weak := getProtocolSecurity(protocol)
 
 	switch {
-	case protocol == "TLS 1.3" && supportedProtocols[protocol]:
-		fmtc.Println("{g}Yes{!}")
-	case protocol == "TLS 1.2":
-		if supportedProtocols[protocol] {
-			fmtc.Println("{g}Yes{!}")
-		} else {
-			fmtc.Println("{y}No{!}")
-		}
-	case protocol == "TLS 1.0", protocol == "TLS 1.1":
-		if supportedProtocols[protocol] {
-			fmtc.Println("{y}Yes{!}")
-		} else {
-			fmtc.Println("No")
-		}
-	case protocol == "SSL 3.0" && supportedProtocols[protocol]:
-		fmtc.Printfn("{r}%s (INSECURE){!}", printBool(supportedProtocols[protocol]))
-	case protocol == "SSL 2.0" && supportedProtocols[protocol]:
-		fmtc.Printfn("{r}%s (INSECURE){!}", printBool(supportedProtocols[protocol]))
+	case !supported && protocol == "TLS 1.2":
+		fmtc.Fprintln(w, "{y}No{!}")
+	case !supported:
+		fmtc.Fprintln(w, "No")
+	case insecure:
+		fmtc.Fprintln(w, "{r}Yes (INSECURE){!}")
+	case weak:
+		fmtc.Fprintln(w, "{y}Yes{!}")
 	default:
-		fmtc.Printfn("%s", printBool(supportedProtocols[protocol]))
+		fmtc.Fprintln(w, "{g}Yes{!}")
 	}
 }
 
 // printProtocolSuitesInfo prints info about suites protocol
-func printProtocolSuitesInfo(suites *sslscan.ProtocolSuites, noSNI bool) {
+func printProtocolSuitesInfo(w io.Writer, suites *sslscan.ProtocolSuites, noSNI bool) {
 	header := " " + protocolsNames[suites.Protocol]
 
 	if noSNI {
@@ -579,54 +665,43 @@ func printProtocolSuitesInfo(suites *sslscan.ProtocolSuites, noSNI bool) {
 		header += " {s-}(server has no preference){!}"
 	}
 
-	fmtc.Println(header)
+	fmtc.Fprintln(w, header)
 }
 
 // printProtocolSuiteInfo prints info about cipher suite
-func printProtocolSuiteInfo(suite *sslscan.Suite, chaCha20Preference bool) {
-	insecure := strings.Contains(suite.Name, "_RC4_") || suite.CipherStrength < 112
+func printProtocolSuiteInfo(w io.Writer, suite *sslscan.Suite, chaCha20Preference bool) {
+	insecure, weak := getSuiteSecurity(suite)
 	preferred := false
 
-	weak := isWeakSuite(suite)
-
 	if strings.Contains(suite.Name, "_CHACHA20_") && chaCha20Preference {
 		preferred = true
 	}
 
-	if suite.Q != nil {
-		switch *suite.Q {
-		case 0:
-			insecure = true
-		case 1:
-			weak = true
-		}
-	}
-
 	switch {
 	case insecure:
-		fmtc.Printf(" {r}%-52s{!} {s}|{!} {r}%d (INSECURE){!} ", suite.Name, suite.CipherStrength)
+		fmtc.Fprintf(w, " {r}%-52s{!} {s}|{!} {r}%d (INSECURE){!} ", suite.Name, suite.CipherStrength)
 	case weak:
-		fmtc.Printf(" {y}%-52s{!} {s}|{!} {y}%d (WEAK){!} ", suite.Name, suite.CipherStrength)
+		fmtc.Fprintf(w, " {y}%-52s{!} {s}|{!} {y}%d (WEAK){!} ", suite.Name, suite.CipherStrength)
 	case preferred:
-		fmtc.Printf(" {*}%-52s{!} {s}|{!} %d ", suite.Name, suite.CipherStrength)
+		fmtc.Fprintf(w, " {*}%-52s{!} {s}|{!} %d ", suite.Name, suite.CipherStrength)
 	default:
-		fmtc.Printf(" %-52s {s}|{!} %d ", suite.Name, suite.CipherStrength)
+		fmtc.Fprintf(w, " %-52s {s}|{!} %d ", suite.Name, suite.CipherStrength)
 	}
 
 	switch {
 	case suite.KxType == "DH":
-		fmtc.Printfn("{s-}(DH %d bits){!}",
+		fmtc.Fprintfn(w, "{s-}(DH %d bits){!}",
 			suite.KxStrength)
 	case suite.NamedGroupName != "":
-		fmtc.Printfn("{s-}(%s %s ~ %d bits RSA){!}",
+		fmtc.Fprintfn(w, "{s-}(%s %s ~ %d bits RSA){!}",
 			suite.KxType, suite.NamedGroupName, suite.KxStrength)
 	default:
-		fmtc.NewLine()
+		fmtc.Fprintln(w)
 	}
 }
 
 // printSimulationInfo prints info about client simulation
-func printSimulationInfo(sim *sslscan.SIM, suites []*sslscan.ProtocolSuites) {
+func printSimulationInfo(w io.Writer, sim *sslscan.SIM, suites []*sslscan.ProtocolSuites) {
 	tag := "{s-}No FS{!}"
 	suite := findSuite(suites, sim.ProtocolID, sim.SuiteID)
 
@@ -651,14 +726,14 @@ func printSimulationInfo(sim *sslscan.SIM, suites []*sslscan.ProtocolSuites) {
 	}
 
 	if sim.Client.IsReference {
-		fmtc.Printf(
+		fmtc.Fprintf(w,
 			" %s {s}|{!} ",
 			fmtutil.Align(fmtc.Sprintf(
 				"%s %s {g}R{!}", sim.Client.Name, sim.Client.Version,
 			), fmtutil.LEFT, 20),
 		)
 	} else {
-		fmtc.Printf(
+		fmtc.Fprintf(w,
 			" %s {s}|{!} ",
 			fmtutil.Align(fmtc.Sprintf(
 				"%s %s", sim.Client.Name, sim.Client.Version,
@@ -668,22 +743,22 @@ func printSimulationInfo(sim *sslscan.SIM, suites []*sslscan.ProtocolSuites) {
 
 	switch protocolsNames[sim.ProtocolID] {
 	case "TLS 1.2", "TLS 1.3":
-		fmtc.Printfn("{g}%-7s{!} %-50s "+tag+" %d",
+		fmtc.Fprintfn(w, "{g}%-7s{!} %-50s "+tag+" %d",
 			protocolsNames[sim.ProtocolID],
 			suite.Name, suite.CipherStrength,
 		)
 	case "TLS 1.1", "TLS 1.0":
-		fmtc.Printfn("{y}%-7s{!} %-50s "+tag+" %d",
+		fmtc.Fprintfn(w, "{y}%-7s{!} %-50s "+tag+" %d",
 			protocolsNames[sim.ProtocolID],
 			suite.Name, suite.CipherStrength,
 		)
 	case "SSL 2.0", "SSL 3.0":
-		fmtc.Printfn("{r}%-7s{!} %-50s "+tag+" %d",
+		fmtc.Fprintfn(w, "{r}%-7s{!} %-50s "+tag+" %d",
 			protocolsNames[sim.ProtocolID],
 			suite.Name, suite.CipherStrength,
 		)
 	default:
-		fmtc.Printfn("%-7s %-50s "+tag+" %d",
+		fmtc.Fprintfn(w, "%-7s %-50s "+tag+" %d",
 			protocolsNames[sim.ProtocolID],
 			suite.Name, suite.CipherStrength,
 		)
@@ -691,303 +766,303 @@ func printSimulationInfo(sim *sslscan.SIM, suites []*sslscan.ProtocolSuites) {
 }
 
 // printEndpointRenegotiationInfo prints info about renegotiation
-func printEndpointRenegotiationInfo(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "Secure Renegotiation")
+func printEndpointRenegotiationInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Secure Renegotiation")
 
 	if details.RenegSupport == 0 {
-		fmtc.Println("{y}Not supported{!}")
+		fmtc.Fprintln(w, "{y}Not supported{!}")
 	} else {
-		fmtc.Println("{g}Supported{!}")
+		fmtc.Fprintln(w, "{g}Supported{!}")
 	}
 
-	fmtc.Printf(" %-40s {s}|{!} ", "Secure Client-Initiated Renegotiation")
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Secure Client-Initiated Renegotiation")
 
 	if details.RenegSupport&4 == 4 {
-		fmtc.Println("Yes")
+		fmtc.Fprintln(w, "Yes")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 
-	fmtc.Printf(" %-40s {s}|{!} ", "Insecure Client-Initiated Renegotiation")
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Insecure Client-Initiated Renegotiation")
 
 	if details.RenegSupport&1 == 1 {
-		fmtc.Println("{r}Supported (INSECURE){!}")
+		fmtc.Fprintln(w, "{r}Supported (INSECURE){!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointPoodleStatus prints status of POODLE vulnerability
-func printEndpointPoodleStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "POODLE (SSLv3)")
+func printEndpointPoodleStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "POODLE (SSLv3)")
 
 	if details.Poodle {
-		fmtc.Println("{r}Vulnerable (INSECURE){!}")
+		fmtc.Fprintln(w, "{r}Vulnerable (INSECURE){!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 
-	fmtc.Printf(" %-40s {s}|{!} ", "POODLE (TLS)")
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "POODLE (TLS)")
 
 	if details.PoodleTLS == 2 {
-		fmtc.Println("{r}Vulnerable (INSECURE){!}")
+		fmtc.Fprintln(w, "{r}Vulnerable (INSECURE){!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 
-	fmtc.Printf(" %-40s {s}|{!} ", "Zombie POODLE")
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Zombie POODLE")
 
 	if details.ZombiePoodle == 2 {
-		fmtc.Println("{r}Vulnerable{!}")
+		fmtc.Fprintln(w, "{r}Vulnerable{!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 
-	fmtc.Printf(" %-40s {s}|{!} ", "GOLDENDOODLE")
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "GOLDENDOODLE")
 
 	if details.GoldenDoodle == 2 {
-		fmtc.Println("{r}Vulnerable{!}")
+		fmtc.Fprintln(w, "{r}Vulnerable{!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 
-	fmtc.Printf(" %-40s {s}|{!} ", "OpenSSL 0-Length")
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "OpenSSL 0-Length")
 
 	if details.ZeroLengthPaddingOracle == 2 {
-		fmtc.Println("{r}Vulnerable{!}")
+		fmtc.Fprintln(w, "{r}Vulnerable{!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 
-	fmtc.Printf(" %-40s {s}|{!} ", "Sleeping POODLE")
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Sleeping POODLE")
 
 	if details.SleepingPoodle == 2 {
-		fmtc.Println("{r}Vulnerable{!}")
+		fmtc.Fprintln(w, "{r}Vulnerable{!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointDrownStatus prints status of DROWN vulnerability
-func printEndpointDrownStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "DROWN")
+func printEndpointDrownStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "DROWN")
 
 	switch {
 	case details.DrownErrors:
-		fmtc.Println("{y}Unable to perform this test due to an internal error{!}")
+		fmtc.Fprintln(w, "{y}Unable to perform this test due to an internal error{!}")
 	case details.DrownVulnerable:
-		fmtc.Println("{r}Vulnerable{!}")
+		fmtc.Fprintln(w, "{r}Vulnerable{!}")
 	default:
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointLogjamStatus prints status of Logjam vulnerability
-func printEndpointLogjamStatus(details *sslscan.EndpointDetails) {
+func printEndpointLogjamStatus(w io.Writer, details *sslscan.EndpointDetails) {
 	if details.Logjam {
-		fmtc.Printfn(" %-40s {s}|{!} {r}Vulnerable{!}", "Logjam")
+		fmtc.Fprintfn(w, " %-40s {s}|{!} {r}Vulnerable{!}", "Logjam")
 	} else {
-		fmtc.Printfn(" %-40s {s}|{!} No", "Logjam")
+		fmtc.Fprintfn(w, " %-40s {s}|{!} No", "Logjam")
 	}
 }
 
 // printEndpointFreakStatus prints status of Freak vulnerability
-func printEndpointFreakStatus(details *sslscan.EndpointDetails) {
+func printEndpointFreakStatus(w io.Writer, details *sslscan.EndpointDetails) {
 	if details.Freak {
-		fmtc.Printfn(" %-40s {s}|{!} {r}Vulnerable{!}", "Freak")
+		fmtc.Fprintfn(w, " %-40s {s}|{!} {r}Vulnerable{!}", "Freak")
 	} else {
-		fmtc.Printfn(" %-40s {s}|{!} No", "Freak")
+		fmtc.Fprintfn(w, " %-40s {s}|{!} No", "Freak")
 	}
 }
 
 // printEndpointFallbackSCSVStatus prints status of downgrade attack prevention
-func printEndpointFallbackSCSVStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "Downgrade attack prevention")
+func printEndpointFallbackSCSVStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Downgrade attack prevention")
 
 	if !details.FallbackSCSV {
-		fmtc.Println("{y}No, TLS_FALLBACK_SCSV not supported{!}")
+		fmtc.Fprintln(w, "{y}No, TLS_FALLBACK_SCSV not supported{!}")
 	} else {
-		fmtc.Println("{g}Yes, TLS_FALLBACK_SCSV supported{!}")
+		fmtc.Fprintln(w, "{g}Yes, TLS_FALLBACK_SCSV supported{!}")
 	}
 }
 
 // printEndpointCompressionInfo prints status of SSL/TLS compression
-func printEndpointCompressionInfo(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "SSL/TLS compression")
+func printEndpointCompressionInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "SSL/TLS compression")
 
 	if details.CompressionMethods != 0 {
-		fmtc.Println("{r}Vulnerable (INSECURE){!}")
+		fmtc.Fprintln(w, "{r}Vulnerable (INSECURE){!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointRC4SupportStatus prints status of RC4 support
-func printEndpointRC4SupportStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "RC4")
+func printEndpointRC4SupportStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "RC4")
 
 	if details.SupportsRC4 {
-		fmtc.Println("{r}Yes (INSECURE){!}")
+		fmtc.Fprintln(w, "{r}Yes (INSECURE){!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointHeartbeatStatus prints status of Heartbeat vulnerability
-func printEndpointHeartbeatStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printfn(" %-40s {s}|{!} %s", "Heartbeat (extension)", printBool(details.Heartbeat))
+func printEndpointHeartbeatStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintfn(w, " %-40s {s}|{!} %s", "Heartbeat (extension)", printBool(details.Heartbeat))
 }
 
 // printEndpointHeartbleedStatus prints status of Heartbleed vulnerability
-func printEndpointHeartbleedStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "Heartbleed (vulnerability)")
+func printEndpointHeartbleedStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Heartbleed (vulnerability)")
 
 	if details.Heartbleed {
-		fmtc.Println("{r}Vulnerable (INSECURE){!}")
+		fmtc.Fprintln(w, "{r}Vulnerable (INSECURE){!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointTicketbleedStatus prints status of Ticketbleed vulnerability
-func printEndpointTicketbleedStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "Ticketbleed (vulnerability)")
+func printEndpointTicketbleedStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Ticketbleed (vulnerability)")
 
 	switch details.Ticketbleed {
 	case sslscan.TICKETBLEED_STATUS_FAILED:
-		fmtc.Println("{y}Test failed{!}")
+		fmtc.Fprintln(w, "{y}Test failed{!}")
 	case sslscan.TICKETBLEED_STATUS_UNKNOWN:
-		fmtc.Println("{y}Unknown{!}")
+		fmtc.Fprintln(w, "{y}Unknown{!}")
 	case sslscan.TICKETBLEED_STATUS_NOT_VULNERABLE:
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	case sslscan.TICKETBLEED_STATUS_VULNERABLE:
-		fmtc.Println("{r}Vulnerable and insecure{!}")
+		fmtc.Fprintln(w, "{r}Vulnerable and insecure{!}")
 	}
 }
 
 // printEndpointOpenSSLCCSStatus prints status of OpenSSL CCS vulnerability
-func printEndpointOpenSSLCCSStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "OpenSSL CCS vuln.")
+func printEndpointOpenSSLCCSStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "OpenSSL CCS vuln.")
 
 	switch details.OpenSSLCCS {
 	case sslscan.SSLCSC_STATUS_FAILED:
-		fmtc.Println("{y}Test failed{!}")
+		fmtc.Fprintln(w, "{y}Test failed{!}")
 	case sslscan.SSLCSC_STATUS_UNKNOWN:
-		fmtc.Println("{y}Unknown{!}")
+		fmtc.Fprintln(w, "{y}Unknown{!}")
 	case sslscan.SSLCSC_STATUS_NOT_VULNERABLE:
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	case sslscan.SSLCSC_STATUS_POSSIBLE_VULNERABLE:
-		fmtc.Println("{y}Possibly vulnerable, but not exploitable{!}")
+		fmtc.Fprintln(w, "{y}Possibly vulnerable, but not exploitable{!}")
 	case sslscan.SSLCSC_STATUS_VULNERABLE:
-		fmtc.Println("{r}Vulnerable and exploitable{!}")
+		fmtc.Fprintln(w, "{r}Vulnerable and exploitable{!}")
 	}
 }
 
 // printEndpointLuckyMinus20Status prints status of OpenSSL Padding Oracle vulnerability
-func printEndpointLuckyMinus20Status(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "OpenSSL Padding Oracle vuln.")
+func printEndpointLuckyMinus20Status(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "OpenSSL Padding Oracle vuln.")
 
 	switch details.OpenSSLLuckyMinus20 {
 	case sslscan.LUCKY_MINUS_STATUS_FAILED:
-		fmtc.Println("{y}Test failed{!}")
+		fmtc.Fprintln(w, "{y}Test failed{!}")
 	case sslscan.LUCKY_MINUS_STATUS_UNKNOWN:
-		fmtc.Println("{y}Unknown{!}")
+		fmtc.Fprintln(w, "{y}Unknown{!}")
 	case sslscan.LUCKY_MINUS_STATUS_NOT_VULNERABLE:
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	case sslscan.LUCKY_MINUS_STATUS_VULNERABLE:
-		fmtc.Println("{r}Vulnerable and insecure{!}")
+		fmtc.Fprintln(w, "{r}Vulnerable and insecure{!}")
 	}
 }
 
 // printEndpointRobotStatus prints status of Bleichenbacher vulnerability
-func printEndpointRobotStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "ROBOT (vulnerability)")
+func printEndpointRobotStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "ROBOT (vulnerability)")
 
 	switch details.Bleichenbacher {
 	case sslscan.BLEICHENBACHER_STATUS_FAILED:
-		fmtc.Println("{y}Test failed{!}")
+		fmtc.Fprintln(w, "{y}Test failed{!}")
 	case sslscan.BLEICHENBACHER_STATUS_UNKNOWN:
-		fmtc.Println("{y}Unknown{!}")
+		fmtc.Fprintln(w, "{y}Unknown{!}")
 	case sslscan.BLEICHENBACHER_STATUS_NOT_VULNERABLE:
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	case sslscan.BLEICHENBACHER_STATUS_VULNERABLE_WEAK:
-		fmtc.Println("{r}Vulnerable (weak oracle){!}")
+		fmtc.Fprintln(w, "{r}Vulnerable (weak oracle){!}")
 	case sslscan.BLEICHENBACHER_STATUS_VULNERABLE_STRONG:
-		fmtc.Println("{r}Vulnerable (strong oracle){!}")
+		fmtc.Fprintln(w, "{r}Vulnerable (strong oracle){!}")
 	case sslscan.BLEICHENBACHER_STATUS_INCONSISTENT_RESULTS:
-		fmtc.Println("{y}Inconsistent results{!}")
+		fmtc.Fprintln(w, "{y}Inconsistent results{!}")
 	}
 }
 
 // printEndpointFSStatus prints status of Forward Secrecy support
-func printEndpointFSStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "Forward Secrecy")
+func printEndpointFSStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Forward Secrecy")
 
 	switch {
 	case isInsecureForwardSecrecy:
-		fmtc.Println("{r}Insecure key exchange{!}")
+		fmtc.Fprintln(w, "{r}Insecure key exchange{!}")
 	case isWeakForwardSecrecy:
-		fmtc.Println("{y}Weak key exchange{!}")
+		fmtc.Fprintln(w, "{y}Weak key exchange{!}")
 	case details.ForwardSecrecy == 0:
-		fmtc.Println("{y}No (WEAK){!}")
+		fmtc.Fprintln(w, "{y}No (WEAK){!}")
 	case details.ForwardSecrecy&1 == 1:
-		fmtc.Println("{y}With some browsers{!}")
+		fmtc.Fprintln(w, "{y}With some browsers{!}")
 	case details.ForwardSecrecy&2 == 2:
-		fmtc.Println("With modern browsers")
+		fmtc.Fprintln(w, "With modern browsers")
 	case details.ForwardSecrecy&4 == 4:
-		fmtc.Println("{g}Yes (with most browsers) (ROBUST){!}")
+		fmtc.Fprintln(w, "{g}Yes (with most browsers) (ROBUST){!}")
 	}
 }
 
 // printEndpointALPNStatus prints status and info about ALPN support
-func printEndpointALPNStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "ALPN")
+func printEndpointALPNStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "ALPN")
 
 	if details.SupportsALPN {
-		fmtc.Printfn("Yes {s-}(%s){!}", details.ALPNProtocols)
+		fmtc.Fprintfn(w, "Yes {s-}(%s){!}", details.ALPNProtocols)
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointNPNStatus prints status and info about NPN support
-func printEndpointNPNStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "NPN")
+func printEndpointNPNStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "NPN")
 
 	if details.SupportsNPN {
-		fmtc.Printfn("Yes {s-}(%s){!}", details.NPNProtocols)
+		fmtc.Fprintfn(w, "Yes {s-}(%s){!}", details.NPNProtocols)
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointSNIStatus prints info about SNI requirements
-func printEndpointSNIStatus(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "SNI Required")
+func printEndpointSNIStatus(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "SNI Required")
 
 	if details.SNIRequired {
-		fmtc.Println("Yes")
+		fmtc.Fprintln(w, "Yes")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointSessionsInfo prints info about sessions features
-func printEndpointSessionsInfo(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "Session resumption (caching)")
+func printEndpointSessionsInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Session resumption (caching)")
 
 	switch details.SessionResumption {
 	case 0:
-		fmtc.Println("{y}No (Session resumption is not enabled){!}")
+		fmtc.Fprintln(w, "{y}No (Session resumption is not enabled){!}")
 	case 1:
-		fmtc.Println("{y}No (IDs assigned but not accepted){!}")
+		fmtc.Fprintln(w, "{y}No (IDs assigned but not accepted){!}")
 	case 2:
-		fmtc.Println("Yes")
+		fmtc.Fprintln(w, "Yes")
 	default:
-		fmtc.Println("Unknown")
+		fmtc.Fprintln(w, "Unknown")
 	}
 
-	fmtc.Printfn(
+	fmtc.Fprintfn(w,
 		" %-40s {s}|{!} %s", "Session resumption (tickets)",
 		printBool(details.SessionTickets&1 == 1),
 	)
@@ -995,112 +1070,112 @@ func printEndpointSessionsInfo(details *sslscan.EndpointDetails) {
 }
 
 // printEndpointStaplingInfo prints status of OCSP stapling support
-func printEndpointStaplingInfo(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "OCSP stapling")
+func printEndpointStaplingInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "OCSP stapling")
 
 	if details.OCSPStapling {
-		fmtc.Println("{g}Yes{!}")
+		fmtc.Fprintln(w, "{g}Yes{!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointHSTSInfo prints info about HSTS
-func printEndpointHSTSInfo(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "Strict Transport Security (HSTS)")
+func printEndpointHSTSInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Strict Transport Security (HSTS)")
 
 	if details.HSTSPolicy != nil && details.HSTSPolicy.Status == sslscan.HSTS_STATUS_PRESENT {
-		fmtc.Printfn("{g}Yes{!} {s-}(%s){!}", details.HSTSPolicy.Header)
+		fmtc.Fprintfn(w, "{g}Yes{!} {s-}(%s){!}", details.HSTSPolicy.Header)
 
 		if len(details.HSTSPreloads) != 0 {
-			fmtc.Printf(" %-40s {s}|{!} ", "HSTS Preloading")
-			fmtc.Println(getHSTSPreloadingMarkers(details.HSTSPreloads))
+			fmtc.Fprintf(w, " %-40s {s}|{!} ", "HSTS Preloading")
+			fmtc.Fprintln(w, getHSTSPreloadingMarkers(details.HSTSPreloads))
 		}
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointHPKPInfo prints info about HPKP
-func printEndpointHPKPInfo(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "Public Key Pinning (HPKP)")
+func printEndpointHPKPInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Public Key Pinning (HPKP)")
 
-	printPolicyInfo(details.HPKPPolicy)
+	printPolicyInfo(w, details.HPKPPolicy)
 
-	fmtc.Printf(" %-40s {s}|{!} ", "Public Key Pinning Report-Only")
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Public Key Pinning Report-Only")
 
-	printPolicyInfo(details.HPKPRoPolicy)
+	printPolicyInfo(w, details.HPKPRoPolicy)
 }
 
 // printEndpointHandshakeInfo prints info about long handshake intolerance
-func printEndpointHandshakeInfo(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "Long handshake intolerance")
+func printEndpointHandshakeInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Long handshake intolerance")
 
 	switch {
 	case details.MiscIntolerance&2 == 2:
-		fmtc.Println("{y}Yes{!}")
+		fmtc.Fprintln(w, "{y}Yes{!}")
 	case details.MiscIntolerance&4 == 4:
-		fmtc.Println("{y}Yes{!} {s-}(workaround success){!}")
+		fmtc.Fprintln(w, "{y}Yes{!} {s-}(workaround success){!}")
 	default:
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointTLSInfo prints info about TLS extension intolerance
-func printEndpointTLSInfo(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "TLS extension intolerance")
+func printEndpointTLSInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "TLS extension intolerance")
 
 	if details.MiscIntolerance&1 == 1 {
-		fmtc.Println("{y}Yes{!}")
+		fmtc.Fprintln(w, "{y}Yes{!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 
-	fmtc.Printf(" %-40s {s}|{!} ", "TLS version intolerance")
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "TLS version intolerance")
 
 	if details.ProtocolIntolerance != 0 {
-		fmtc.Printfn("{y}%s{!}", getProtocolIntolerance(details.ProtocolIntolerance))
+		fmtc.Fprintfn(w, "{y}%s{!}", getProtocolIntolerance(details.ProtocolIntolerance))
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointDHPrimesInfo prints info about DH primes
-func printEndpointDHPrimesInfo(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "Uses common DH primes")
+func printEndpointDHPrimesInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Uses common DH primes")
 
 	if details.DHUsesKnownPrimes != 0 {
-		fmtc.Println("{y}Yes (Replace with custom DH parameters if possible){!}")
+		fmtc.Fprintln(w, "{y}Yes (Replace with custom DH parameters if possible){!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 
-	fmtc.Printf(" %-40s {s}|{!} ", "DH public server param (Ys) reuse")
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "DH public server param (Ys) reuse")
 
 	if details.DHYsReuse {
-		fmtc.Println("{y}Yes{!}")
+		fmtc.Fprintln(w, "{y}Yes{!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointECDHInfo prints info about ECDH param reuse
-func printEndpointECDHInfo(details *sslscan.EndpointDetails) {
-	fmtc.Printf(" %-40s {s}|{!} ", "ECDH public server param reuse")
+func printEndpointECDHInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "ECDH public server param reuse")
 
 	if details.ECDHParameterReuse {
-		fmtc.Println("{y}Yes{!}")
+		fmtc.Fprintln(w, "{y}Yes{!}")
 	} else {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printEndpointNamedGroups prints list with supported named groups
-func printEndpointNamedGroups(namedGroups *sslscan.NamedGroups) {
-	fmtc.Printf(" %-40s {s}|{!} ", "Supported Named Groups")
+func printEndpointNamedGroups(w io.Writer, namedGroups *sslscan.NamedGroups) {
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Supported Named Groups")
 
 	if namedGroups == nil || len(namedGroups.List) == 0 {
-		fmtc.Println("—")
+		fmtc.Fprintln(w, "—")
 		return
 	}
 
@@ -1110,88 +1185,88 @@ func printEndpointNamedGroups(namedGroups *sslscan.NamedGroups) {
 		groups = append(groups, group.Name)
 	}
 
-	fmtc.Print(strings.Join(groups, ", "))
+	fmtc.Fprint(w, strings.Join(groups, ", "))
 
 	if namedGroups.Preference {
-		fmtc.Printfn(" {s-}(server preferred order){!}")
+		fmtc.Fprintfn(w, " {s-}(server preferred order){!}")
 	}
 }
 
 // print0RTTStatus prints 0-RTT support status
-func print0RTTStatus(status int) {
+func print0RTTStatus(w io.Writer, status int) {
 	if status == -1 {
 		return
 	}
 
-	fmtc.Printf(" %-40s {s}|{!} ", "0-RTT")
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "0-RTT")
 
 	switch status {
 	case -2:
-		fmtc.Println("Test failed")
+		fmtc.Fprintln(w, "Test failed")
 	case 0:
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	case 1:
-		fmtc.Println("{g}Yes{!}")
+		fmtc.Fprintln(w, "{g}Yes{!}")
 	}
 }
 
 // printPolicyInfo prints info about HPKP policy
-func printPolicyInfo(policy *sslscan.HPKPPolicy) {
+func printPolicyInfo(w io.Writer, policy *sslscan.HPKPPolicy) {
 	if policy == nil {
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 		return
 	}
 
 	switch policy.Status {
 	case sslscan.HPKP_STATUS_INVALID:
-		fmtc.Println("{r}Invalid{!}")
+		fmtc.Fprintln(w, "{r}Invalid{!}")
 	case sslscan.HPKP_STATUS_DISABLED:
-		fmtc.Println("{y}Disabled{!}")
+		fmtc.Fprintln(w, "{y}Disabled{!}")
 	case sslscan.HPKP_STATUS_INCOMPLETE:
-		fmtc.Println("{y}Incomplete{!}")
+		fmtc.Fprintln(w, "{y}Incomplete{!}")
 	case sslscan.HPKP_STATUS_VALID:
-		fmtc.Printf("{g}Yes{!} ")
+		fmtc.Fprintf(w, "{g}Yes{!} ")
 
 		if policy.IncludeSubDomains {
-			fmtc.Printfn(
+			fmtc.Fprintfn(w,
 				"{s-}(max-age=%d; includeSubdomains){!}",
 				policy.MaxAge,
 			)
 		} else {
-			fmtc.Printfn(
+			fmtc.Fprintfn(w,
 				"{s-}(max-age=%d){!}",
 				policy.MaxAge,
 			)
 		}
 
 		for _, pin := range getPinsFromPolicy(policy) {
-			fmtc.Printfn(" %-40s {s}|{!} {s-}%s{!}", "", pin)
+			fmtc.Fprintfn(w, " %-40s {s}|{!} {s-}%s{!}", "", pin)
 		}
 	default:
-		fmtc.Println("No")
+		fmtc.Fprintln(w, "No")
 	}
 }
 
 // printTestInfo prints basic info about test
-func printTestInfo(info *sslscan.EndpointInfo) {
+func printTestInfo(w io.Writer, info *sslscan.EndpointInfo) {
 	details := info.Details
 	testDate := time.Unix(info.Details.HostStartTime/1000, 0)
 
-	fmtc.Printfn(
+	fmtc.Fprintfn(w,
 		" %-24s {s}|{!} %s {s-}(%s ago){!}", "Test date",
 		timeutil.Format(testDate, "%Y/%m/%d %H:%M:%S"),
 		timeutil.PrettyDuration(time.Since(testDate)),
 	)
 
-	fmtc.Printfn(
+	fmtc.Fprintfn(w,
 		" %-24s {s}|{!} %s", "Test duration",
 		timeutil.PrettyDuration(info.Duration/1000),
 	)
 
 	if details.HTTPStatusCode == 0 {
-		fmtc.Printfn(" %-24s {s}|{!} {y}Request failed{!}", "HTTP status code")
+		fmtc.Fprintfn(w, " %-24s {s}|{!} {y}Request failed{!}", "HTTP status code")
 	} else {
-		fmtc.Printfn(
+		fmtc.Fprintfn(w,
 			" %-24s {s}|{!} %d {s-}(%s){!}", "HTTP status code",
 			details.HTTPStatusCode,
 			httputil.GetDescByCode(details.HTTPStatusCode),
@@ -1200,27 +1275,27 @@ func printTestInfo(info *sslscan.EndpointInfo) {
 }
 
 // printWebServerInfo prints basic info about web server
-func printWebServerInfo(info *sslscan.EndpointInfo) {
+func printWebServerInfo(w io.Writer, info *sslscan.EndpointInfo) {
 	details := info.Details
 
 	if details.HTTPForwarding != "" {
 		if strings.Contains(details.HTTPForwarding, "http://") {
-			fmtc.Printfn(" %-24s {s}|{!} {y}%s (PLAINTEXT){!}", "HTTP forwarding", details.HTTPForwarding)
+			fmtc.Fprintfn(w, " %-24s {s}|{!} {y}%s (PLAINTEXT){!}", "HTTP forwarding", details.HTTPForwarding)
 		} else {
-			fmtc.Printfn(" %-24s {s}|{!} %s", "HTTP forwarding", details.HTTPForwarding)
+			fmtc.Fprintfn(w, " %-24s {s}|{!} %s", "HTTP forwarding", details.HTTPForwarding)
 		}
 	}
 
 	if details.ServerSignature != "" {
-		fmtc.Printfn(" %-24s {s}|{!} %s", "HTTP server signature", details.ServerSignature)
+		fmtc.Fprintfn(w, " %-24s {s}|{!} %s", "HTTP server signature", details.ServerSignature)
 	} else {
-		fmtc.Printfn(" %-24s {s}|{!} Unknown", "HTTP server signature")
+		fmtc.Fprintfn(w, " %-24s {s}|{!} Unknown", "HTTP server signature")
 	}
 
 	if info.ServerName != "" {
-		fmtc.Printfn(" %-24s {s}|{!} %s", "Server hostname", info.ServerName)
+		fmtc.Fprintfn(w, " %-24s {s}|{!} %s", "Server hostname", info.ServerName)
 	} else {
-		fmtc.Printfn(" %-24s {s}|{!} —", "Server hostname")
+		fmtc.Fprintfn(w, " %-24s {s}|{!} —", "Server hostname")
 	}
 }
 
@@ -1419,6 +1494,45 @@ func findCertByID(certs []*sslscan.Cert, certID string) *sslscan.Cert {
 	return nil
 }
 
+// getSuiteSecurity returns insecure and weak flags for cipher suite
+func getSuiteSecurity(suite *sslscan.Suite) (bool, bool) {
+	insecure := strings.Contains(suite.Name, "_RC4_") || suite.CipherStrength < 112
+	weak := isWeakSuite(suite)
+
+	if suite.Q != nil {
+		switch *suite.Q {
+		case 0:
+			insecure = true
+		case 1:
+			weak = true
+		}
+	}
+
+	return insecure, weak
+}
+
+// getProtocolSecurity returns insecure and weak flags for protocol
+func getProtocolSecurity(protocol string) (bool, bool) {
+	switch protocol {
+	case "SSL 2.0", "SSL 3.0":
+		return true, false
+	case "TLS 1.0", "TLS 1.1":
+		return false, true
+	}
+
+	return false, false
+}
+
+// isWeakCertKey returns true if certificate key is weak
+func isWeakCertKey(cert *sslscan.Cert) bool {
+	return cert.KeyAlg == "RSA" && cert.KeyStrength < 2048
+}
+
+// getValidDays returns number of days before certificate expiry
+func getValidDays(cert *sslscan.Cert) int64 {
+	return (cert.NotAfter/1000 - time.Now().Unix()) / 86400
+}
+
 // isWeakSuite returns true if suite is weak
 func isWeakSuite(suite *sslscan.Suite) bool {
 	if suite.KxType == "DH" && suite.KxStrength < 2048 {
@@ -1436,6 +1550,30 @@ func isWeakSuite(suite *sslscan.Suite) bool {
 	return false
 }
 
+// getVulnerabilities returns status of all known vulnerabilities
+func getVulnerabilities(details *sslscan.EndpointDetails) []vulnInfo {
+	return []vulnInfo{
+		{"Insecure Renegotiation", details.RenegSupport&1 == 1},
+		{"POODLE (SSLv3)", details.Poodle},
+		{"POODLE (TLS)", details.PoodleTLS == 2},
+		{"Zombie POODLE", details.ZombiePoodle == 2},
+		{"GOLDENDOODLE", details.GoldenDoodle == 2},
+		{"OpenSSL 0-Length", details.ZeroLengthPaddingOracle == 2},
+		{"Sleeping POODLE", details.SleepingPoodle == 2},
+		{"DROWN", details.DrownVulnerable},
+		{"Logjam", details.Logjam},
+		{"Freak", details.Freak},
+		{"SSL/TLS compression", details.CompressionMethods != 0},
+		{"RC4", details.SupportsRC4},
+		{"Heartbleed", details.Heartbleed},
+		{"Ticketbleed", details.Ticketbleed == sslscan.TICKETBLEED_STATUS_VULNERABLE},
+		{"OpenSSL CCS", details.OpenSSLCCS == sslscan.SSLCSC_STATUS_VULNERABLE},
+		{"OpenSSL Padding Oracle", details.OpenSSLLuckyMinus20 == sslscan.LUCKY_MINUS_STATUS_VULNERABLE},
+		{"ROBOT", details.Bleichenbacher == sslscan.BLEICHENBACHER_STATUS_VULNERABLE_WEAK ||
+			details.Bleichenbacher == sslscan.BLEICHENBACHER_STATUS_VULNERABLE_STRONG},
+	}
+}
+
 // extractSubject extracts subject name from certificate subject
 func extractSubject(data string) string {
 	subject := strutil.ReadField(data, 0, false, ',')
@@ -1480,30 +1618,24 @@ func getTrustInfo(certID string, endpoints []*sslscan.EndpointInfo) (map[string]
 	return result, true
 }
 
-// getExpiryMessage returns message if cert is expired in given period
-func getExpiryMessage(ap *sslscan.AnalyzeProgress, dur time.Duration) string {
-	if dur <= 0 {
+// getExpiryMessage returns message if any certificate from chains or trust
+// paths is expired in given period
+func getExpiryMessage(info *sslscan.AnalyzeInfo, dur time.Duration) string {
+	if dur <= 0 || info == nil {
 		return ""
 	}
 
-	info, err := ap.Info(true, true)
-
-	if err != nil || strings.ToUpper(info.Status) != "READY" || len(info.Certs) == 0 {
+	if strings.ToUpper(info.Status) != "READY" || len(info.Certs) == 0 {
 		return ""
 	}
 
-	cert := info.Certs[0]
-	validUntilDate := time.Unix(cert.NotAfter/1000, 0)
+	expiring := getExpiringCerts(info, dur)
 
-	if time.Until(validUntilDate) > dur {
+	if len(expiring) == 0 {
 		return ""
 	}
 
-	validDays := (validUntilDate.Unix() - time.Now().Unix()) / 86400
+	message := formatExpiringCerts(expiring, len(info.Endpoints))
 
-	return fmt.Sprintf(
-		" {r}(expires in %s %s){!}",
-		fmtutil.PrettyNum(validDays),
-		pluralize.Pluralize(int(validDays), "day", "days"),
-	)
+	return " {r}(" + strings.ReplaceAll(message, "%", "%%") + "){!}"
 }
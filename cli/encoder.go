@@ -10,7 +10,10 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"html"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 )
 
@@ -19,6 +22,11 @@ import (
 // encodeAsText print check info in simple text format
 func encodeAsText(checksInfo []*HostCheckInfo) {
 	for _, info := range checksInfo {
+		if info.Error != nil {
+			fmt.Printf("%s Err %s\n", info.Host, info.Error.Error())
+			continue
+		}
+
 		grades := []string{}
 
 		for _, endpoint := range info.Endpoints {
@@ -47,23 +55,105 @@ func encodeAsXML(checksInfo []*HostCheckInfo) {
 
 	for _, info := range checksInfo {
 		fmt.Printf(
-			"  <host name=\"%s\" lowest=\"%s\" highest=\"%s\" lowestNum=\"%.1f\" highestNum=\"%.1f\">\n",
-			info.Host, info.LowestGrade, info.HighestGrade, info.LowestGradeNum, info.HighestGradeNum,
+			"  <host name=\"%s\" lowest=\"%s\" highest=\"%s\" lowestNum=\"%.1f\" highestNum=\"%.1f\" retries=\"%d\">\n",
+			info.Host, info.LowestGrade, info.HighestGrade, info.LowestGradeNum, info.HighestGradeNum, info.Retries,
 		)
 
 		if len(info.Endpoints) != 0 {
 			fmt.Println("    <endpoints>")
 
 			for _, endpoint := range info.Endpoints {
+				if len(endpoint.ExpiringCerts) == 0 && len(endpoint.SecurityHeaders) == 0 &&
+					len(endpoint.SuitesOrder) == 0 {
+					fmt.Printf(
+						"      <endpoint ip=\"%s\" grade=\"%s\" grade=\"%.1f\" />\n",
+						endpoint.IPAddress, endpoint.Grade, endpoint.GradeNum,
+					)
+
+					continue
+				}
+
 				fmt.Printf(
-					"      <endpoint ip=\"%s\" grade=\"%s\" grade=\"%.1f\" />\n",
+					"      <endpoint ip=\"%s\" grade=\"%s\" grade=\"%.1f\">\n",
 					endpoint.IPAddress, endpoint.Grade, endpoint.GradeNum,
 				)
+
+				for _, cert := range endpoint.ExpiringCerts {
+					fmt.Printf(
+						"        <expiring type=\"%s\" subject=\"%s\" notAfter=\"%s\" daysLeft=\"%d\" />\n",
+						cert.Type, html.EscapeString(cert.Subject), cert.NotAfter, cert.DaysLeft,
+					)
+				}
+
+				for _, check := range endpoint.SecurityHeaders {
+					fmt.Printf(
+						"        <header id=\"%s\" name=\"%s\" status=\"%s\">%s</header>\n",
+						check.ID, check.Name, check.Status, html.EscapeString(check.Message),
+					)
+				}
+
+				for _, problem := range endpoint.SuitesOrder {
+					fmt.Printf(
+						"        <suitesOrder severity=\"%s\" protocol=\"%s\">%s</suitesOrder>\n",
+						problem.Severity, problem.Protocol, html.EscapeString(problem.Message),
+					)
+				}
+
+				fmt.Println("      </endpoint>")
 			}
 
 			fmt.Println("    </endpoints>")
 		}
 
+		if len(info.Tags) != 0 {
+			fmt.Println("    <tags>")
+
+			for _, name := range slices.Sorted(maps.Keys(info.Tags)) {
+				fmt.Printf(
+					"      <tag name=\"%s\" value=\"%s\" />\n",
+					html.EscapeString(name), html.EscapeString(info.Tags[name]),
+				)
+			}
+
+			fmt.Println("    </tags>")
+		}
+
+		if len(info.Violations) != 0 {
+			fmt.Println("    <violations>")
+
+			for _, violation := range info.Violations {
+				fmt.Printf("      <violation>%s</violation>\n", html.EscapeString(violation))
+			}
+
+			fmt.Println("    </violations>")
+		}
+
+		if info.HSTSPreload != nil {
+			fmt.Printf(
+				"    <hstsPreload domain=\"%s\" status=\"%s\" preloadedIn=\"%s\">\n",
+				info.HSTSPreload.Domain, info.HSTSPreload.Status,
+				strings.Join(info.HSTSPreload.PreloadedIn, ","),
+			)
+
+			for _, problem := range info.HSTSPreload.Problems {
+				fmt.Printf("      <problem>%s</problem>\n", html.EscapeString(problem))
+			}
+
+			for _, warning := range info.HSTSPreload.Warnings {
+				fmt.Printf("      <warning>%s</warning>\n", html.EscapeString(warning))
+			}
+
+			fmt.Println("    </hstsPreload>")
+		}
+
+		if info.Error != nil {
+			fmt.Printf(
+				"    <error phase=\"%s\" statusCode=\"%d\" retryable=\"%t\">%s</error>\n",
+				info.Error.Phase, info.Error.StatusCode, info.Error.Retryable,
+				html.EscapeString(info.Error.Message),
+			)
+		}
+
 		fmt.Println("  </host>")
 	}
 
@@ -85,6 +175,41 @@ func encodeAsYAML(checksInfo []*HostCheckInfo) {
 			fmt.Printf("        grade: %s\n", endpoint.Grade)
 			fmt.Printf("        gradeNum: %.1f\n", endpoint.GradeNum)
 			fmt.Printf("        ipAddress: \"%s\"\n", endpoint.IPAddress)
+
+			if len(endpoint.ExpiringCerts) != 0 {
+				fmt.Println("        expiringCerts:")
+
+				for _, cert := range endpoint.ExpiringCerts {
+					fmt.Println("          -")
+					fmt.Printf("            type: %s\n", cert.Type)
+					fmt.Printf("            subject: %q\n", cert.Subject)
+					fmt.Printf("            notAfter: %q\n", cert.NotAfter)
+					fmt.Printf("            daysLeft: %d\n", cert.DaysLeft)
+				}
+			}
+
+			if len(endpoint.SecurityHeaders) != 0 {
+				fmt.Println("        securityHeaders:")
+
+				for _, check := range endpoint.SecurityHeaders {
+					fmt.Println("          -")
+					fmt.Printf("            id: %s\n", check.ID)
+					fmt.Printf("            name: %s\n", check.Name)
+					fmt.Printf("            status: %s\n", check.Status)
+					fmt.Printf("            message: %q\n", check.Message)
+				}
+			}
+
+			if len(endpoint.SuitesOrder) != 0 {
+				fmt.Println("        suitesOrder:")
+
+				for _, problem := range endpoint.SuitesOrder {
+					fmt.Println("          -")
+					fmt.Printf("            severity: %s\n", problem.Severity)
+					fmt.Printf("            protocol: %q\n", problem.Protocol)
+					fmt.Printf("            message: %q\n", problem.Message)
+				}
+			}
 		}
 
 		fmt.Printf("    host: %s\n", info.Host)
@@ -92,5 +217,56 @@ func encodeAsYAML(checksInfo []*HostCheckInfo) {
 		fmt.Printf("    highestGradeNum: %.1f\n", info.HighestGradeNum)
 		fmt.Printf("    lowestGrade: %s\n", info.LowestGrade)
 		fmt.Printf("    lowestGradeNum: %.1f\n", info.LowestGradeNum)
+		fmt.Printf("    retries: %d\n", info.Retries)
+
+		if len(info.Tags) != 0 {
+			fmt.Println("    tags:")
+
+			for _, name := range slices.Sorted(maps.Keys(info.Tags)) {
+				fmt.Printf("      %s: %q\n", name, info.Tags[name])
+			}
+		}
+
+		if len(info.Violations) != 0 {
+			fmt.Println("    violations:")
+
+			for _, violation := range info.Violations {
+				fmt.Printf("      - %q\n", violation)
+			}
+		}
+
+		if info.HSTSPreload != nil {
+			fmt.Println("    hstsPreload:")
+			fmt.Printf("      domain: %s\n", info.HSTSPreload.Domain)
+			fmt.Printf("      status: %s\n", info.HSTSPreload.Status)
+
+			if len(info.HSTSPreload.PreloadedIn) != 0 {
+				fmt.Printf("      preloadedIn: [%s]\n", strings.Join(info.HSTSPreload.PreloadedIn, ", "))
+			}
+
+			if len(info.HSTSPreload.Problems) != 0 {
+				fmt.Println("      problems:")
+
+				for _, problem := range info.HSTSPreload.Problems {
+					fmt.Printf("        - %q\n", problem)
+				}
+			}
+
+			if len(info.HSTSPreload.Warnings) != 0 {
+				fmt.Println("      warnings:")
+
+				for _, warning := range info.HSTSPreload.Warnings {
+					fmt.Printf("        - %q\n", warning)
+				}
+			}
+		}
+
+		if info.Error != nil {
+			fmt.Println("    error:")
+			fmt.Printf("      phase: %s\n", info.Error.Phase)
+			fmt.Printf("      statusCode: %d\n", info.Error.StatusCode)
+			fmt.Printf("      message: %q\n", info.Error.Message)
+			fmt.Printf("      retryable: %t\n", info.Error.Retryable)
+		}
 	}
 }
@@ -0,0 +1,133 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/timeutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	PHASE_INIT       = "init"       // API client initialization
+	PHASE_ANALYZE    = "analyze"    // Starting assessment
+	PHASE_INFO       = "info"       // Fetching assessment progress
+	PHASE_DETAILS    = "details"    // Fetching full assessment info
+	PHASE_ASSESSMENT = "assessment" // Assessment finished with error
+	PHASE_TIMEOUT    = "timeout"    // Assessment didn't finish in time
+	PHASE_CANCELLED  = "cancelled"  // Check cancelled by user
+	PHASE_SKIPPED    = "skipped"    // Host doesn't serve HTTPS
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CheckError contains info about check error
+type CheckError struct {
+	Phase      string `json:"phase"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Message    string `json:"message"`
+	Retryable  bool   `json:"retryable"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// statusCodeRegex is regexp for extracting HTTP status code from API error
+var statusCodeRegex = regexp.MustCompile(`(?i)(?:code|status)[^0-9]{0,3}([1-5][0-9]{2})\b`)
+
+// retryableStatusCodes contains SSL Labs API status codes for temporary errors
+var retryableStatusCodes = map[int]bool{
+	429: true, // Rate limit exceeded or too many concurrent assessments
+	500: true, // Internal error
+	503: true, // Service is not available
+	529: true, // Service is overloaded
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newRequestError creates check error from API request error
+func newRequestError(phase string, err error) *CheckError {
+	return &CheckError{
+		Phase:      phase,
+		StatusCode: getErrorStatusCode(err),
+		Message:    err.Error(),
+		Retryable:  isRetryableError(err),
+	}
+}
+
+// newAssessmentError creates check error from assessment status message
+func newAssessmentError(message string) *CheckError {
+	return &CheckError{
+		Phase:   PHASE_ASSESSMENT,
+		Message: message,
+	}
+}
+
+// newTimeoutError creates check error for assessment which didn't finish in time
+func newTimeoutError() *CheckError {
+	if !runDeadline.IsZero() && time.Now().After(runDeadline) {
+		return &CheckError{Phase: PHASE_TIMEOUT, Message: "Run deadline exceeded"}
+	}
+
+	return &CheckError{
+		Phase:   PHASE_TIMEOUT,
+		Message: "Assessment didn't finish in " + timeutil.ShortDuration(hostTimeout),
+	}
+}
+
+// newCancelledError creates check error for check cancelled by user
+func newCancelledError() *CheckError {
+	return &CheckError{Phase: PHASE_CANCELLED, Message: "Check cancelled by user"}
+}
+
+// newSkippedError creates check error for host which doesn't serve HTTPS
+func newSkippedError(reason string) *CheckError {
+	return &CheckError{Phase: PHASE_SKIPPED, Message: reason}
+}
+
+// isRetryableError returns true if request error is temporary and request
+// can be retried
+func isRetryableError(err error) bool {
+	var netErr net.Error
+
+	return retryableStatusCodes[getErrorStatusCode(err)] || errors.As(err, &netErr)
+}
+
+// getErrorStatusCode extracts HTTP status code from API error
+func getErrorStatusCode(err error) int {
+	match := statusCodeRegex.FindStringSubmatch(err.Error())
+
+	if len(match) != 2 {
+		return 0
+	}
+
+	code, _ := strconv.Atoi(match[1])
+
+	return code
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Error returns error description
+func (e *CheckError) Error() string {
+	if e == nil {
+		return ""
+	}
+
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("%s (phase: %s, status code: %d)", e.Message, e.Phase, e.StatusCode)
+	}
+
+	return fmt.Sprintf("%s (phase: %s)", e.Message, e.Phase)
+}
@@ -0,0 +1,194 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/pluralize"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ExpiringCert contains info about certificate from endpoint chain or trust
+// path which expires soon
+type ExpiringCert struct {
+	Type     string `json:"type"`
+	Subject  string `json:"subject"`
+	NotAfter string `json:"notAfter"`
+	DaysLeft int64  `json:"daysLeft"`
+
+	id       string    // Certificate ID
+	notAfter time.Time // Certificate expiry date
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getExpiringCerts returns map endpoint IP → certificates from served chains
+// and trust paths which expire in given period
+func getExpiringCerts(info *sslscan.AnalyzeInfo, dur time.Duration) map[string][]*ExpiringCert {
+	result := make(map[string][]*ExpiringCert)
+
+	for _, endpoint := range info.Endpoints {
+		var certs []*ExpiringCert
+
+		for _, cert := range getEndpointCerts(info, endpoint) {
+			if time.Until(cert.notAfter) <= dur {
+				certs = append(certs, cert)
+			}
+		}
+
+		if len(certs) != 0 {
+			result[endpoint.IPAddress] = certs
+		}
+	}
+
+	return result
+}
+
+// getEndpointCerts returns all certificates from chains served by endpoint
+// and their trust paths
+//
+// If endpoint details are not available, only the first certificate from
+// assessment is returned.
+func getEndpointCerts(info *sslscan.AnalyzeInfo, endpoint *sslscan.EndpointInfo) []*ExpiringCert {
+	var result []*ExpiringCert
+
+	addCert := func(certID string, isLeaf bool) {
+		cert := findCertByID(info.Certs, certID)
+
+		if cert == nil || slices.ContainsFunc(result, func(c *ExpiringCert) bool {
+			return c.id == certID
+		}) {
+			return
+		}
+
+		result = append(result, newExpiringCert(cert, isLeaf))
+	}
+
+	if endpoint.Details == nil || len(endpoint.Details.CertChains) == 0 {
+		if len(info.Certs) != 0 {
+			addCert(info.Certs[0].ID, true)
+		}
+
+		return result
+	}
+
+	for _, chain := range endpoint.Details.CertChains {
+		for index, certID := range chain.CertIDs {
+			addCert(certID, index == 0)
+		}
+
+		for _, path := range chain.TrustPaths {
+			for index, certID := range path.CertIDs {
+				addCert(certID, index == 0)
+			}
+		}
+	}
+
+	return result
+}
+
+// newExpiringCert creates expiring certificate info
+func newExpiringCert(cert *sslscan.Cert, isLeaf bool) *ExpiringCert {
+	certType := CERT_TYPE_INTERMEDIATE
+
+	switch {
+	case isLeaf:
+		certType = CERT_TYPE_LEAF
+	case cert.Subject == cert.IssuerSubject:
+		certType = CERT_TYPE_ROOT
+	}
+
+	notAfter := time.Unix(cert.NotAfter/1000, 0).UTC()
+
+	return &ExpiringCert{
+		Type:     certType,
+		Subject:  extractSubject(cert.Subject),
+		NotAfter: notAfter.Format(time.RFC3339),
+		DaysLeft: getValidDays(cert),
+		id:       cert.ID,
+		notAfter: notAfter,
+	}
+}
+
+// formatExpiringCerts returns description of expiring certificates for all
+// endpoints
+//
+// If certificate expires on all endpoints, endpoint addresses are omitted.
+func formatExpiringCerts(expiring map[string][]*ExpiringCert, endpointsNum int) string {
+	var result []string
+	var ids []string
+
+	endpoints := make(map[string][]string)
+	certs := make(map[string]*ExpiringCert)
+
+	for _, ip := range slices.Sorted(maps.Keys(expiring)) {
+		for _, cert := range expiring[ip] {
+			if certs[cert.id] == nil {
+				ids = append(ids, cert.id)
+				certs[cert.id] = cert
+			}
+
+			endpoints[cert.id] = append(endpoints[cert.id], ip)
+		}
+	}
+
+	slices.SortStableFunc(ids, func(a, b string) int {
+		return cmp.Compare(certs[a].DaysLeft, certs[b].DaysLeft)
+	})
+
+	for _, id := range ids {
+		message := formatExpiringCert(certs[id])
+
+		if len(endpoints[id]) != endpointsNum {
+			message = strings.Join(endpoints[id], ", ") + ": " + message
+		}
+
+		result = append(result, message)
+	}
+
+	return strings.Join(result, "; ")
+}
+
+// formatExpiringCert returns description of expiring certificate
+func formatExpiringCert(cert *ExpiringCert) string {
+	if cert.DaysLeft < 0 {
+		return fmt.Sprintf("%s certificate %s expired", cert.Type, cert.Subject)
+	}
+
+	return fmt.Sprintf(
+		"%s certificate %s expires in %s %s",
+		cert.Type, cert.Subject, fmtutil.PrettyNum(cert.DaysLeft),
+		pluralize.Pluralize(int(cert.DaysLeft), "day", "days"),
+	)
+}
+
+// appendExpiringCerts appends info about expiring certificates to endpoints
+// info and returns true if there is at least one expiring certificate
+func appendExpiringCerts(checkInfo *HostCheckInfo, dur time.Duration) bool {
+	if checkInfo.info == nil || checkInfo.info.Status != sslscan.STATUS_READY {
+		return false
+	}
+
+	expiring := getExpiringCerts(checkInfo.info, dur)
+
+	for _, endpoint := range checkInfo.Endpoints {
+		endpoint.ExpiringCerts = expiring[endpoint.IPAddress]
+	}
+
+	return len(expiring) != 0
+}
@@ -0,0 +1,308 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	HEADER_STATUS_PASS = "pass"
+	HEADER_STATUS_WARN = "warn"
+	HEADER_STATUS_FAIL = "fail"
+)
+
+const (
+	HEADER_HSTS      = "hsts"
+	HEADER_CSP       = "csp"
+	HEADER_XCTO      = "xcto"
+	HEADER_REFERRER  = "referrer"
+	HEADER_COOKIES   = "cookies"
+	HEADER_EXPECT_CT = "expect-ct"
+)
+
+// HSTS_MIN_MAX_AGE is minimal recommended HSTS max-age (180 days)
+const HSTS_MIN_MAX_AGE = 15552000
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// HeaderCheck contains result of security header check
+type HeaderCheck struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// headerChecks contains list of all supported header checks
+var headerChecks = []string{
+	HEADER_HSTS, HEADER_CSP, HEADER_XCTO, HEADER_REFERRER, HEADER_COOKIES, HEADER_EXPECT_CT,
+}
+
+// requirableHeaders contains list of header checks which can be required by
+// policy (Expect-CT is deprecated and its check never fails)
+var requirableHeaders = []string{
+	HEADER_HSTS, HEADER_CSP, HEADER_XCTO, HEADER_REFERRER, HEADER_COOKIES,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkSecurityHeaders evaluates security headers from HTTP responses captured
+// by SSL Labs
+func checkSecurityHeaders(details *sslscan.EndpointDetails) []*HeaderCheck {
+	if details == nil || len(details.HTTPTransactions) == 0 {
+		return nil
+	}
+
+	// Use headers of the final response after all redirects
+	headers := details.HTTPTransactions[len(details.HTTPTransactions)-1].ResponseHeaders
+
+	return []*HeaderCheck{
+		checkHSTSHeader(details.HSTSPolicy),
+		checkCSPHeader(headers),
+		checkXCTOHeader(headers),
+		checkReferrerHeader(headers),
+		checkCookies(details.HTTPTransactions),
+		checkExpectCTHeader(headers),
+	}
+}
+
+// checkHSTSHeader checks HSTS policy
+func checkHSTSHeader(hsts *sslscan.HSTSPolicy) *HeaderCheck {
+	result := &HeaderCheck{ID: HEADER_HSTS, Name: "Strict-Transport-Security"}
+
+	minMaxAge := int64(HSTS_MIN_MAX_AGE)
+
+	if hsts != nil && hsts.LongMaxAge > 0 {
+		minMaxAge = hsts.LongMaxAge
+	}
+
+	switch {
+	case hsts == nil, hsts.Status == sslscan.HSTS_STATUS_ABSENT,
+		hsts.Status == sslscan.HSTS_STATUS_UNKNOWN:
+		result.Status, result.Message = HEADER_STATUS_FAIL, "Header is not set"
+	case hsts.Status == sslscan.HSTS_STATUS_INVALID:
+		result.Status, result.Message = HEADER_STATUS_FAIL, "Header is invalid: "+hsts.Error
+	case hsts.Status == sslscan.HSTS_STATUS_DISABLED, hsts.MaxAge == 0:
+		result.Status, result.Message = HEADER_STATUS_FAIL, "HSTS is disabled (max-age=0)"
+	case hsts.MaxAge < minMaxAge:
+		result.Status = HEADER_STATUS_WARN
+		result.Message = fmt.Sprintf("max-age is too short (%d days)", hsts.MaxAge/86400)
+	case !hsts.IncludeSubDomains:
+		result.Status, result.Message = HEADER_STATUS_WARN, "includeSubDomains is not set"
+	default:
+		result.Status, result.Message = HEADER_STATUS_PASS, hsts.Header
+	}
+
+	return result
+}
+
+// checkCSPHeader checks Content-Security-Policy header
+func checkCSPHeader(headers []*sslscan.HTTPHeader) *HeaderCheck {
+	result := &HeaderCheck{ID: HEADER_CSP, Name: "Content-Security-Policy"}
+
+	csp := getHeaderValue(headers, "Content-Security-Policy")
+
+	switch {
+	case csp == "" && getHeaderValue(headers, "Content-Security-Policy-Report-Only") != "":
+		result.Status, result.Message = HEADER_STATUS_WARN, "Policy is in report-only mode"
+	case csp == "":
+		result.Status, result.Message = HEADER_STATUS_FAIL, "Header is not set"
+	case strings.Contains(csp, "'unsafe-inline'"), strings.Contains(csp, "'unsafe-eval'"):
+		result.Status, result.Message = HEADER_STATUS_WARN, "Policy allows unsafe inline scripts or eval"
+	default:
+		result.Status, result.Message = HEADER_STATUS_PASS, "Header is set"
+	}
+
+	return result
+}
+
+// checkXCTOHeader checks X-Content-Type-Options header
+func checkXCTOHeader(headers []*sslscan.HTTPHeader) *HeaderCheck {
+	result := &HeaderCheck{ID: HEADER_XCTO, Name: "X-Content-Type-Options"}
+
+	value := getHeaderValue(headers, "X-Content-Type-Options")
+
+	switch {
+	case value == "":
+		result.Status, result.Message = HEADER_STATUS_FAIL, "Header is not set"
+	case !strings.EqualFold(value, "nosniff"):
+		result.Status, result.Message = HEADER_STATUS_WARN, "Unsupported value "+value
+	default:
+		result.Status, result.Message = HEADER_STATUS_PASS, value
+	}
+
+	return result
+}
+
+// checkReferrerHeader checks Referrer-Policy header
+func checkReferrerHeader(headers []*sslscan.HTTPHeader) *HeaderCheck {
+	result := &HeaderCheck{ID: HEADER_REFERRER, Name: "Referrer-Policy"}
+
+	value := getHeaderValue(headers, "Referrer-Policy")
+
+	// Header can contain list of policies, the last one supported by browser is used
+	policies := strings.Split(strings.ToLower(value), ",")
+	policyName := strings.TrimSpace(policies[len(policies)-1])
+
+	switch policyName {
+	case "":
+		result.Status, result.Message = HEADER_STATUS_WARN, "Header is not set, browser default is used"
+	case "unsafe-url":
+		result.Status, result.Message = HEADER_STATUS_FAIL, "Full URL is sent to all origins"
+	case "no-referrer-when-downgrade", "origin", "origin-when-cross-origin":
+		result.Status, result.Message = HEADER_STATUS_WARN, "Policy leaks data to other origins ("+policyName+")"
+	default:
+		result.Status, result.Message = HEADER_STATUS_PASS, policyName
+	}
+
+	return result
+}
+
+// checkCookies checks that all cookies set over HTTPS have Secure flag
+func checkCookies(transactions []*sslscan.HTTPTransaction) *HeaderCheck {
+	var total int
+	var insecure []string
+
+	result := &HeaderCheck{ID: HEADER_COOKIES, Name: "Set-Cookie"}
+
+	for _, transaction := range transactions {
+		reqURL, err := url.Parse(transaction.RequestURL)
+
+		// Cookies set over plain HTTP (e.g. on redirect to HTTPS) are not
+		// covered by this check
+		if err != nil || reqURL.Scheme != "https" {
+			continue
+		}
+
+		for _, header := range transaction.ResponseHeaders {
+			if !strings.EqualFold(header.Name, "Set-Cookie") {
+				continue
+			}
+
+			total++
+
+			name, attrs, _ := strings.Cut(header.Value, ";")
+			name, _, _ = strings.Cut(name, "=")
+			name = strings.TrimSpace(name)
+
+			if !hasCookieAttr(attrs, "secure") && !slices.Contains(insecure, name) {
+				insecure = append(insecure, name)
+			}
+		}
+	}
+
+	switch {
+	case total == 0:
+		result.Status, result.Message = HEADER_STATUS_PASS, "No cookies"
+	case len(insecure) != 0:
+		result.Status = HEADER_STATUS_FAIL
+		result.Message = "Cookies without Secure flag: " + strings.Join(insecure, ", ")
+	default:
+		result.Status, result.Message = HEADER_STATUS_PASS, "All cookies have Secure flag"
+	}
+
+	return result
+}
+
+// checkExpectCTHeader checks Expect-CT header
+func checkExpectCTHeader(headers []*sslscan.HTTPHeader) *HeaderCheck {
+	result := &HeaderCheck{ID: HEADER_EXPECT_CT, Name: "Expect-CT"}
+
+	if getHeaderValue(headers, "Expect-CT") == "" {
+		result.Status, result.Message = HEADER_STATUS_PASS, "Header is not set"
+	} else {
+		result.Status, result.Message = HEADER_STATUS_WARN, "Header is deprecated and ignored by browsers"
+	}
+
+	return result
+}
+
+// getHeaderValue returns value of header with given name
+func getHeaderValue(headers []*sslscan.HTTPHeader, name string) string {
+	for _, header := range headers {
+		if strings.EqualFold(header.Name, name) {
+			return strings.TrimSpace(header.Value)
+		}
+	}
+
+	return ""
+}
+
+// hasCookieAttr returns true if cookie attributes contain attribute with
+// given name
+func hasCookieAttr(attrs, name string) bool {
+	for _, attr := range strings.Split(attrs, ";") {
+		attrName, _, _ := strings.Cut(attr, "=")
+
+		if strings.EqualFold(strings.TrimSpace(attrName), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// appendSecurityHeaders appends security headers checks to endpoints info
+func appendSecurityHeaders(checkInfo *HostCheckInfo) {
+	if checkInfo.info == nil {
+		return
+	}
+
+	for index, endpoint := range checkInfo.info.Endpoints {
+		if index < len(checkInfo.Endpoints) {
+			checkInfo.Endpoints[index].SecurityHeaders = checkSecurityHeaders(endpoint.Details)
+		}
+	}
+}
+
+// formatHeaderChecks returns headers checks as space-separated list of
+// id:status pairs
+func formatHeaderChecks(checks []*HeaderCheck) string {
+	var result []string
+
+	for _, check := range checks {
+		result = append(result, check.ID+":"+check.Status)
+	}
+
+	return strings.Join(result, " ")
+}
+
+// printSecurityHeadersInfo prints info about security headers
+func printSecurityHeadersInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	checks := checkSecurityHeaders(details)
+
+	if len(checks) == 0 {
+		return
+	}
+
+	printCategoryHeader(w, "Security Headers")
+
+	for _, check := range checks {
+		switch check.Status {
+		case HEADER_STATUS_PASS:
+			fmtc.Fprintfn(w, " %-40s {s}|{!} {g}Pass{!} {s-}(%s){!}", check.Name, check.Message)
+		case HEADER_STATUS_WARN:
+			fmtc.Fprintfn(w, " %-40s {s}|{!} {y}Warn{!} {s-}(%s){!}", check.Name, check.Message)
+		default:
+			fmtc.Fprintfn(w, " %-40s {s}|{!} {r}Fail{!} {s-}(%s){!}", check.Name, check.Message)
+		}
+	}
+}
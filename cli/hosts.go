@@ -0,0 +1,291 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/terminal"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// HOSTS_STDIN is name of argument for reading hosts from standard input
+const HOSTS_STDIN = "-"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// hostEntry contains host and tags with info about its source
+type hostEntry struct {
+	Host string
+	Tags map[string]string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// hostTags contains tags for every host
+var hostTags map[string]map[string]string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getHosts returns list of hosts defined as arguments, in list files or
+// passed through standard input
+func getHosts(args options.Arguments) ([]string, error) {
+	var result []string
+	var stdinRead bool
+
+	importType := options.GetS(OPT_IMPORT)
+	filter := getHostFilter()
+	hostTags = make(map[string]map[string]string)
+
+	for _, arg := range args.Strings() {
+		var entries []*hostEntry
+		var err error
+
+		switch {
+		case arg == HOSTS_STDIN:
+			if stdinRead {
+				continue
+			}
+
+			entries, err = readHosts(os.Stdin, "stdin", importType)
+			stdinRead = true
+
+			if err != nil {
+				return nil, fmt.Errorf("Can't read hosts from standard input: %w", err)
+			}
+
+		case importType != "" && fsutil.CheckPerms("DRX", arg):
+			entries, err = importHostsFromDir(arg, importType)
+
+			if err != nil {
+				return nil, fmt.Errorf("Can't import hosts from %s: %w", arg, err)
+			}
+
+		case fsutil.CheckPerms("FR", arg):
+			entries, err = readHostList(arg, importType)
+
+			if err != nil {
+				return nil, err
+			}
+
+		default:
+			entries = []*hostEntry{{Host: arg}}
+		}
+
+		for _, entry := range entries {
+			host, err := normalizeHost(entry.Host)
+
+			if err != nil {
+				if !options.GetB(OPT_QUIET) {
+					terminal.Warn(err)
+				}
+
+				continue
+			}
+
+			if host == "" || !isHostMatchFilter(host, filter) {
+				continue
+			}
+
+			if hostTags[host] == nil {
+				result = append(result, host)
+				hostTags[host] = make(map[string]string)
+			}
+
+			mergeHostTags(hostTags[host], entry.Tags)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("There are no hosts to check")
+	}
+
+	return result, nil
+}
+
+// readHostList reads hosts from list file or imports them from file with
+// configuration
+func readHostList(file, importType string) ([]*hostEntry, error) {
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer fd.Close()
+
+	result, err := readHosts(fd, file, importType)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read hosts from %s: %w", file, err)
+	}
+
+	if len(result) == 0 && importType == "" {
+		return nil, fmt.Errorf("File with hosts %s is empty", file)
+	}
+
+	return result, nil
+}
+
+// readHosts reads hosts from list or imports them from configuration
+func readHosts(r io.Reader, file, importType string) ([]*hostEntry, error) {
+	if importType != "" {
+		return importHosts(r, file, importType)
+	}
+
+	hosts, err := parseHostList(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*hostEntry
+
+	for _, host := range hosts {
+		result = append(result, &hostEntry{Host: host})
+	}
+
+	return result, nil
+}
+
+// parseHostList parses list with hosts, one host per line
+//
+// Blank lines and comments (starting with #) are ignored.
+func parseHostList(r io.Reader) ([]string, error) {
+	var result []string
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if index := strings.IndexByte(line, '#'); index != -1 {
+			line = line[:index]
+		}
+
+		line = strings.TrimSpace(line)
+
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+
+	return result, scanner.Err()
+}
+
+// normalizeHost converts URL or host:port pair to host name
+func normalizeHost(host string) (string, error) {
+	source := host
+
+	if strings.Contains(host, "://") {
+		u, err := url.Parse(host)
+
+		if err != nil || u.Host == "" {
+			return "", fmt.Errorf("Can't parse URL %q", source)
+		}
+
+		host = u.Host
+	} else if index := strings.IndexByte(host, '/'); index != -1 {
+		host = host[:index]
+	}
+
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		if port != "443" {
+			return "", fmt.Errorf("Skipping %s: SSL Labs can check only port 443", source)
+		}
+
+		host = h
+	}
+
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	if strings.HasPrefix(host, "*.") {
+		return "", fmt.Errorf("Skipping %s: wildcard names can't be checked", source)
+	}
+
+	return strings.Trim(host, "[]"), nil
+}
+
+// getHostFilter returns list of host name patterns defined by user
+func getHostFilter() []string {
+	var result []string
+
+	for _, pattern := range strings.Split(options.GetS(OPT_FILTER), ",") {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+		if pattern != "" {
+			result = append(result, pattern)
+		}
+	}
+
+	return result
+}
+
+// validateHostFilter checks host name patterns defined by user
+func validateHostFilter() error {
+	for _, pattern := range getHostFilter() {
+		_, err := path.Match(pattern, "")
+
+		if err != nil {
+			return fmt.Errorf("Invalid host filter pattern %q", pattern)
+		}
+	}
+
+	return nil
+}
+
+// isHostMatchFilter returns true if host matches any of given patterns
+func isHostMatchFilter(host string, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, pattern := range filter {
+		if ok, _ := path.Match(pattern, host); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatTags returns tags as space-separated list of key=value pairs
+func formatTags(tags map[string]string) string {
+	var result []string
+
+	for _, name := range slices.Sorted(maps.Keys(tags)) {
+		result = append(result, name+"="+tags[name])
+	}
+
+	return strings.Join(result, " ")
+}
+
+// mergeHostTags merges tags of host defined in several sources
+func mergeHostTags(tags, newTags map[string]string) {
+	for name, value := range newTags {
+		switch {
+		case tags[name] == "":
+			tags[name] = value
+		case !slices.Contains(strings.Split(tags[name], ", "), value):
+			tags[name] += ", " + value
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestNormalizeHost(t *testing.T) {
+	cases := []struct {
+		Host     string
+		Expected string
+		IsError  bool
+	}{
+		{"domain.com", "domain.com", false},
+		{"Domain.COM.", "domain.com", false},
+		{"domain.com:443", "domain.com", false},
+		{"domain.com/path/to", "domain.com", false},
+		{"https://domain.com", "domain.com", false},
+		{"https://user@domain.com:443/path?query=1", "domain.com", false},
+		{"[2001:db8::1]:443", "2001:db8::1", false},
+		{"https://[2001:db8::1]/", "2001:db8::1", false},
+		{"192.168.1.1", "192.168.1.1", false},
+		{"domain.com:8443", "", true},
+		{"https://domain.com:8443/", "", true},
+		{"*.domain.com", "", true},
+		{"https://", "", true},
+		{"https://%zz", "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Host, func(t *testing.T) {
+			host, err := normalizeHost(tc.Host)
+
+			switch {
+			case tc.IsError && err == nil:
+				t.Errorf("Expected error, got %q", host)
+			case !tc.IsError && err != nil:
+				t.Errorf("Unexpected error: %v", err)
+			case host != tc.Expected:
+				t.Errorf("Expected %q, got %q", tc.Expected, host)
+			}
+		})
+	}
+}
+
+func TestParseHostList(t *testing.T) {
+	data := "domain.com\n\n# comment\n  www.domain.com  # inline comment\n\t\napi.domain.com"
+	expected := []string{"domain.com", "www.domain.com", "api.domain.com"}
+
+	hosts, err := parseHostList(strings.NewReader(data))
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !slices.Equal(hosts, expected) {
+		t.Errorf("Expected %q, got %q", expected, hosts)
+	}
+}
@@ -0,0 +1,276 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/timeutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// htmlReport contains data for HTML report
+type htmlReport struct {
+	App   string
+	Date  string
+	Hosts []*htmlHost
+}
+
+// htmlHost contains info about host for HTML report
+type htmlHost struct {
+	Check       *HostCheckInfo
+	Tags        string
+	GradeClass  string
+	Expiry      string
+	ExpiryClass string
+	Failures    []string
+	Sections    []*htmlSection
+}
+
+// htmlSection contains section of detailed info converted to HTML
+type htmlSection struct {
+	Title   string
+	Content template.HTML
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ansiHTMLClasses is map ANSI color code → CSS class
+var ansiHTMLClasses = map[string]string{
+	"31": "r", "32": "g", "33": "y", "34": "b",
+	"35": "m", "36": "c", "37": "s", "90": "sl",
+	"91": "r", "92": "g", "93": "y", "94": "b",
+	"95": "m", "96": "c", "97": "w",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// encodeAsHTML print check info as self-contained HTML report
+func encodeAsHTML(checksInfo []*HostCheckInfo) {
+	report := &htmlReport{
+		App:  APP + " " + VER,
+		Date: timeutil.Format(time.Now(), "%Y/%m/%d %H:%M:%S"),
+	}
+
+	for _, checkInfo := range checksInfo {
+		report.Hosts = append(report.Hosts, getHTMLHostInfo(checkInfo))
+	}
+
+	tmpl, err := template.New("report").Parse(HTML_TEMPLATE)
+
+	if err != nil {
+		fmt.Println("<!-- Can't render report -->")
+		os.Exit(1)
+	}
+
+	err = tmpl.Execute(os.Stdout, report)
+
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// getHTMLHostInfo converts host check info to HTML report data
+func getHTMLHostInfo(checkInfo *HostCheckInfo) *htmlHost {
+	result := &htmlHost{
+		Check:       checkInfo,
+		Tags:        formatTags(checkInfo.Tags),
+		GradeClass:  getHTMLGradeClass(checkInfo.LowestGrade),
+		Expiry:      "—",
+		ExpiryClass: "sl",
+		Failures:    getPolicyFailures(checkInfo),
+	}
+
+	info := checkInfo.info
+
+	if info != nil && len(info.Certs) != 0 {
+		validDays := getValidDays(info.Certs[0])
+		result.Expiry = fmt.Sprintf(
+			"%s (%d days)",
+			timeutil.Format(time.Unix(info.Certs[0].NotAfter/1000, 0), "%Y/%m/%d"),
+			validDays,
+		)
+
+		switch {
+		case checkInfo.expiredSoon || validDays < 0:
+			result.ExpiryClass = "r"
+		case validDays <= 30:
+			result.ExpiryClass = "y"
+		default:
+			result.ExpiryClass = ""
+		}
+	}
+
+	for _, section := range getDetailedSections(info) {
+		title := section.Title
+
+		if section.Endpoint != "" {
+			title = section.Endpoint + " · " + title
+		}
+
+		result.Sections = append(result.Sections, &htmlSection{
+			Title:   title,
+			Content: template.HTML(ansiToHTML(strings.Join(section.Lines, "\n"))),
+		})
+	}
+
+	return result
+}
+
+// getHTMLGradeClass returns CSS class for grade
+func getHTMLGradeClass(grade string) string {
+	switch grade {
+	case "A", "A-", "A+":
+		return "g"
+	case "B", "C", "D", "E":
+		return "y"
+	}
+
+	return "r"
+}
+
+// ansiToHTML converts text with ANSI escape codes to HTML
+func ansiToHTML(data string) string {
+	var buf strings.Builder
+	var bold, open bool
+	var color string
+
+	for data != "" {
+		index := strings.Index(data, "\x1b[")
+
+		if index == -1 {
+			buf.WriteString(html.EscapeString(data))
+			break
+		}
+
+		buf.WriteString(html.EscapeString(data[:index]))
+		data = data[index+2:]
+
+		end := strings.IndexByte(data, 'm')
+
+		if end == -1 {
+			break
+		}
+
+		for _, code := range strings.Split(data[:end], ";") {
+			switch code {
+			case "", "0":
+				bold, color = false, ""
+			case "1":
+				bold = true
+			case "22":
+				bold = false
+			case "39":
+				color = ""
+			default:
+				if class, ok := ansiHTMLClasses[code]; ok {
+					color = class
+				}
+			}
+		}
+
+		data = data[end+1:]
+
+		if open {
+			buf.WriteString("</span>")
+			open = false
+		}
+
+		if bold || color != "" {
+			classes := color
+
+			if bold {
+				classes = strings.TrimSpace(classes + " bold")
+			}
+
+			buf.WriteString(`<span class="` + classes + `">`)
+			open = true
+		}
+	}
+
+	if open {
+		buf.WriteString("</span>")
+	}
+
+	return buf.String()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// HTML_TEMPLATE is template of self-contained HTML report
+const HTML_TEMPLATE = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>TLS Report · {{.Date}}</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; background: #fafafa; }
+  h1 { font-size: 1.6em; margin-bottom: 0.2em; }
+  h2 { font-size: 1.3em; margin-top: 2em; }
+  .meta { color: #777; margin-bottom: 2em; }
+  table { border-collapse: collapse; width: 100%; background: #fff; }
+  th, td { border: 1px solid #ddd; padding: 6px 10px; text-align: left; vertical-align: top; }
+  th { background: #f0f0f0; }
+  ul.failures { margin: 0; padding-left: 1.2em; }
+  details { margin: 0.4em 0; background: #fff; border: 1px solid #ddd; border-radius: 4px; }
+  details > summary { cursor: pointer; padding: 6px 10px; font-weight: 600; }
+  details details { margin: 0.4em 10px; }
+  pre { margin: 0; padding: 10px; overflow-x: auto; background: #1e1e1e; color: #ddd; font-size: 12px; line-height: 1.4; }
+  .r { color: #e5534b; } .g { color: #3fb950; } .y { color: #d29922; } .b { color: #539bf5; }
+  .m { color: #c678dd; } .c { color: #39c5cf; } .s { color: #aaa; } .sl { color: #777; } .w { color: #fff; }
+  td.r, td.g, td.y { font-weight: 600; }
+  .bold { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>TLS Report</h1>
+<div class="meta">Generated by {{.App}} at {{.Date}}</div>
+
+<table>
+  <tr>
+    <th>Host</th>
+    <th>Lowest Grade</th>
+    <th>Highest Grade</th>
+    <th>Endpoints</th>
+    <th>Certificate Expiry</th>
+    <th>Failed Checks</th>
+  </tr>
+{{- range .Hosts}}
+  <tr>
+    <td><a href="#{{.Check.Host}}">{{.Check.Host}}</a>{{if .Tags}}<br><small class="sl">{{.Tags}}</small>{{end}}</td>
+    <td class="{{.GradeClass}}">{{.Check.LowestGrade}}</td>
+    <td>{{.Check.HighestGrade}}</td>
+    <td>{{range $i, $e := .Check.Endpoints}}{{if $i}}<br>{{end}}{{$e.IPAddress}} ({{$e.Grade}}){{end}}</td>
+    <td class="{{.ExpiryClass}}">{{.Expiry}}</td>
+    <td>{{if .Failures}}<ul class="failures">{{range .Failures}}<li class="r">{{.}}</li>{{end}}</ul>{{else}}<span class="g">None</span>{{end}}</td>
+  </tr>
+{{- end}}
+</table>
+{{range .Hosts}}
+<h2 id="{{.Check.Host}}">{{.Check.Host}}</h2>
+{{- if .Sections}}
+{{- range .Sections}}
+<details>
+  <summary>{{.Title}}</summary>
+  <pre>{{.Content}}</pre>
+</details>
+{{- end}}
+{{- else}}
+<p class="sl">No detailed info available</p>
+{{- end}}
+{{end}}
+</body>
+</html>
+`
@@ -0,0 +1,134 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/terminal"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	IMPORT_K8S       = "k8s"
+	IMPORT_NGINX     = "nginx"
+	IMPORT_APACHE    = "apache"
+	IMPORT_HAPROXY   = "haproxy"
+	IMPORT_CADDY     = "caddy"
+	IMPORT_ZONE      = "zone"
+	IMPORT_TERRAFORM = "terraform"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// importer is function for extracting hosts from configuration file
+type importer func(data []byte, file string) ([]*hostEntry, error)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// importers is map import type → importer
+var importers = map[string]importer{
+	IMPORT_K8S:       importK8sHosts,
+	IMPORT_NGINX:     importNginxHosts,
+	IMPORT_APACHE:    importApacheHosts,
+	IMPORT_HAPROXY:   importHAProxyHosts,
+	IMPORT_CADDY:     importCaddyHosts,
+	IMPORT_ZONE:      importZoneHosts,
+	IMPORT_TERRAFORM: importTerraformHosts,
+}
+
+// importTypes contains list of supported import types
+var importTypes = []string{
+	IMPORT_K8S, IMPORT_NGINX, IMPORT_APACHE, IMPORT_HAPROXY, IMPORT_CADDY,
+	IMPORT_ZONE, IMPORT_TERRAFORM,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// validateImportType checks import type defined by user
+func validateImportType(importType string) error {
+	if !slices.Contains(importTypes, importType) {
+		return fmt.Errorf(
+			"Unknown import type %q (supported types: %s)",
+			importType, strings.Join(importTypes, ", "),
+		)
+	}
+
+	return nil
+}
+
+// importHosts extracts hosts from configuration file with given type
+func importHosts(r io.Reader, file, importType string) ([]*hostEntry, error) {
+	data, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return importers[importType](data, file)
+}
+
+// importHostsFromDir extracts hosts from all configuration files in directory
+//
+// Symlinks to regular files (e.g. in nginx sites-enabled directory) are
+// processed as regular files. Files which can't be parsed are skipped with
+// warning.
+func importHostsFromDir(dir, importType string) ([]*hostEntry, error) {
+	var result []*hostEntry
+
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			if d.Type()&fs.ModeSymlink == 0 {
+				return nil
+			}
+
+			info, err := os.Stat(file)
+
+			if err != nil || !info.Mode().IsRegular() {
+				return nil
+			}
+		}
+
+		entries, err := readHostList(file, importType)
+
+		if err != nil {
+			if !options.GetB(OPT_QUIET) {
+				terminal.Warn(err)
+			}
+
+			return nil
+		}
+
+		result = append(result, entries...)
+
+		return nil
+	})
+
+	return result, err
+}
+
+// newSourceEntry creates host entry with info about config file and line
+// where host is defined
+func newSourceEntry(host, file string, line int) *hostEntry {
+	return &hostEntry{
+		Host: host,
+		Tags: map[string]string{"source": fmt.Sprintf("%s:%d", file, line)},
+	}
+}
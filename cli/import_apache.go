@@ -0,0 +1,70 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// importApacheHosts extracts ServerName and ServerAlias values of TLS-enabled
+// virtual hosts from Apache configuration
+func importApacheHosts(data []byte, file string) ([]*hostEntry, error) {
+	var result []*hostEntry
+	var names []confToken
+	var inVHost, isTLS bool
+
+	for index, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		directive := strings.ToLower(fields[0])
+		args := fields[1:]
+
+		switch {
+		case directive == "<virtualhost":
+			inVHost, isTLS, names = true, false, nil
+
+			for _, addr := range args {
+				if strings.HasSuffix(strings.TrimSuffix(addr, ">"), ":443") {
+					isTLS = true
+				}
+			}
+
+		case directive == "</virtualhost>":
+			if isTLS {
+				for _, name := range names {
+					result = append(result, newSourceEntry(name.Value, file, name.Line))
+				}
+			}
+
+			inVHost = false
+
+		case !inVHost:
+			continue
+
+		case directive == "sslengine":
+			isTLS = len(args) != 0 && strings.EqualFold(args[0], "on")
+
+		case directive == "servername", directive == "serveralias":
+			for _, arg := range args {
+				if strings.HasPrefix(arg, "#") {
+					break
+				}
+
+				names = append(names, confToken{Value: arg, Line: index + 1})
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// importCaddyHosts extracts site addresses served over HTTPS from Caddyfile
+func importCaddyHosts(data []byte, file string) ([]*hostEntry, error) {
+	var result []*hostEntry
+	var depth int
+	var isFirst, isSingleSite = true, false
+
+	for index, line := range strings.Split(string(data), "\n") {
+		line = stripCaddyComment(line)
+
+		if line == "" {
+			continue
+		}
+
+		// Site addresses are defined at top level before site block or on
+		// the first line of Caddyfile with single site
+		if depth == 0 && !isSingleSite && (isFirst || strings.HasSuffix(line, "{")) &&
+			line != "{" && !strings.HasPrefix(line, "(") {
+			isSingleSite = !strings.HasSuffix(line, "{")
+
+			for _, addr := range strings.FieldsFunc(strings.TrimSuffix(line, "{"), isCaddyAddrSeparator) {
+				host := getCaddySiteHost(addr)
+
+				if host != "" {
+					result = append(result, newSourceEntry(host, file, index+1))
+				}
+			}
+		}
+
+		isFirst = false
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		depth = max(depth, 0)
+	}
+
+	return result, nil
+}
+
+// getCaddySiteHost returns host name from site address if site is served
+// over HTTPS
+func getCaddySiteHost(addr string) string {
+	switch {
+	case strings.HasPrefix(addr, "http://"),
+		strings.HasPrefix(addr, ":"),
+		strings.HasPrefix(addr, "{"),
+		strings.HasSuffix(addr, ":80"):
+		return ""
+	}
+
+	host := strings.TrimPrefix(addr, "https://")
+
+	if !strings.Contains(host, ".") {
+		return ""
+	}
+
+	return host
+}
+
+// stripCaddyComment removes comment from Caddyfile line
+func stripCaddyComment(line string) string {
+	line = strings.TrimSpace(line)
+
+	if strings.HasPrefix(line, "#") {
+		return ""
+	}
+
+	if i := strings.Index(line, " #"); i != -1 {
+		line = line[:i]
+	}
+
+	return strings.TrimSpace(line)
+}
+
+// isCaddyAddrSeparator returns true if given rune is site address separator
+func isCaddyAddrSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t'
+}
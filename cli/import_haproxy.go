@@ -0,0 +1,118 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"net"
+	"slices"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// haproxySections contains names of HAProxy configuration sections
+var haproxySections = []string{
+	"global", "defaults", "frontend", "backend", "listen", "peers", "resolvers",
+	"userlist", "program", "cache", "mailers", "http-errors", "ring",
+}
+
+// haproxyHostFetches contains sample fetches which match host name or SNI
+var haproxyHostFetches = []string{
+	"hdr(host)", "hdr_dom(host)", "hdr_end(host)", "req.hdr(host)",
+	"ssl_fc_sni", "ssl_fc_sni_end", "req.ssl_sni", "req_ssl_sni",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// importHAProxyHosts extracts host names from bind addresses and host/SNI ACLs
+// of TLS-enabled frontends from HAProxy configuration
+func importHAProxyHosts(data []byte, file string) ([]*hostEntry, error) {
+	var result []*hostEntry
+	var names []confToken
+	var inFrontend, isTLS bool
+
+	flush := func() {
+		if inFrontend && isTLS {
+			for _, name := range names {
+				result = append(result, newSourceEntry(name.Value, file, name.Line))
+			}
+		}
+	}
+
+	for index, line := range strings.Split(string(data), "\n") {
+		if i := strings.IndexByte(line, '#'); i != -1 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		if slices.Contains(haproxySections, fields[0]) {
+			flush()
+			inFrontend = fields[0] == "frontend" || fields[0] == "listen"
+			isTLS, names = false, nil
+			continue
+		}
+
+		if !inFrontend {
+			continue
+		}
+
+		if fields[0] == "bind" && len(fields) > 1 {
+			host, port, _ := net.SplitHostPort(fields[1])
+
+			if slices.Contains(fields[2:], "ssl") || port == "443" {
+				isTLS = true
+			}
+
+			if host != "" && host != "*" && net.ParseIP(host) == nil {
+				names = append(names, confToken{Value: host, Line: index + 1})
+			}
+
+			continue
+		}
+
+		for _, name := range getHAProxyMatchedHosts(fields) {
+			names = append(names, confToken{Value: name, Line: index + 1})
+		}
+	}
+
+	flush()
+
+	return result, nil
+}
+
+// getHAProxyMatchedHosts returns host names matched by host or SNI fetches
+func getHAProxyMatchedHosts(fields []string) []string {
+	var result []string
+	var inMatch bool
+
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+
+		switch {
+		case slices.Contains(haproxyHostFetches, field):
+			inMatch = true
+		case !inMatch:
+			continue
+		case field == "-m":
+			i++
+		case strings.HasPrefix(field, "-"):
+			continue
+		case field == "}", field == "||", field == "or", field == "if", field == "unless":
+			inMatch = false
+		case strings.Contains(field, "."):
+			result = append(result, strings.TrimPrefix(field, "."))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,216 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	K8S_KIND_LIST        = "List"
+	K8S_KIND_INGRESS     = "Ingress"
+	K8S_KIND_GATEWAY     = "Gateway"
+	K8S_KIND_HTTP_ROUTE  = "HTTPRoute"
+	K8S_KIND_CERTIFICATE = "Certificate"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// k8sObject contains fields of Kubernetes objects used for hosts discovery
+type k8sObject struct {
+	Kind     string       `yaml:"kind"`
+	Metadata k8sMetadata  `yaml:"metadata"`
+	Spec     k8sSpec      `yaml:"spec"`
+	Items    []*k8sObject `yaml:"items"`
+}
+
+// k8sMetadata contains object metadata
+type k8sMetadata struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+}
+
+// k8sSpec contains fields of Ingress, Gateway, HTTPRoute and cert-manager
+// Certificate specs
+type k8sSpec struct {
+	// Ingress
+	TLS []struct {
+		Hosts []string `yaml:"hosts"`
+	} `yaml:"tls"`
+
+	// Gateway
+	Listeners []struct {
+		Hostname string `yaml:"hostname"`
+		Protocol string `yaml:"protocol"`
+	} `yaml:"listeners"`
+
+	// HTTPRoute
+	Hostnames  []string `yaml:"hostnames"`
+	ParentRefs []struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"parentRefs"`
+
+	// Certificate
+	CommonName string   `yaml:"commonName"`
+	DNSNames   []string `yaml:"dnsNames"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// importK8sHosts extracts TLS hosts from Kubernetes manifests
+func importK8sHosts(data []byte, file string) ([]*hostEntry, error) {
+	var objects []*k8sObject
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		obj := &k8sObject{}
+		err := decoder.Decode(obj)
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, flattenK8sObjects(obj)...)
+	}
+
+	var result []*hostEntry
+
+	tlsGateways := getK8sTLSGateways(objects)
+
+	for _, obj := range objects {
+		for _, host := range getK8sObjectHosts(obj, tlsGateways) {
+			result = append(result, &hostEntry{Host: host, Tags: getK8sTags(obj)})
+		}
+	}
+
+	return result, nil
+}
+
+// flattenK8sObjects returns objects from lists
+func flattenK8sObjects(obj *k8sObject) []*k8sObject {
+	if obj.Kind != K8S_KIND_LIST && len(obj.Items) == 0 {
+		return []*k8sObject{obj}
+	}
+
+	var result []*k8sObject
+
+	for _, item := range obj.Items {
+		result = append(result, flattenK8sObjects(item)...)
+	}
+
+	return result
+}
+
+// getK8sTLSGateways returns map with gateways which have HTTPS or TLS listeners
+func getK8sTLSGateways(objects []*k8sObject) map[string]bool {
+	result := make(map[string]bool)
+
+	for _, obj := range objects {
+		if obj.Kind != K8S_KIND_GATEWAY {
+			continue
+		}
+
+		key := obj.Metadata.Namespace + "/" + obj.Metadata.Name
+		result[key] = false
+
+		for _, listener := range obj.Spec.Listeners {
+			if isK8sTLSProtocol(listener.Protocol) {
+				result[key] = true
+			}
+		}
+	}
+
+	return result
+}
+
+// getK8sObjectHosts returns TLS hosts defined in object
+func getK8sObjectHosts(obj *k8sObject, tlsGateways map[string]bool) []string {
+	var result []string
+
+	switch obj.Kind {
+	case K8S_KIND_INGRESS:
+		for _, tls := range obj.Spec.TLS {
+			result = append(result, tls.Hosts...)
+		}
+
+	case K8S_KIND_GATEWAY:
+		for _, listener := range obj.Spec.Listeners {
+			if isK8sTLSProtocol(listener.Protocol) && listener.Hostname != "" {
+				result = append(result, listener.Hostname)
+			}
+		}
+
+	case K8S_KIND_HTTP_ROUTE:
+		if isK8sRouteUsesTLS(obj, tlsGateways) {
+			result = append(result, obj.Spec.Hostnames...)
+		}
+
+	case K8S_KIND_CERTIFICATE:
+		if obj.Spec.CommonName != "" {
+			result = append(result, obj.Spec.CommonName)
+		}
+
+		result = append(result, obj.Spec.DNSNames...)
+	}
+
+	return result
+}
+
+// isK8sRouteUsesTLS returns true if route attached to gateway with HTTPS or
+// TLS listener or gateway is unknown
+func isK8sRouteUsesTLS(obj *k8sObject, tlsGateways map[string]bool) bool {
+	if len(obj.Spec.ParentRefs) == 0 {
+		return true
+	}
+
+	for _, ref := range obj.Spec.ParentRefs {
+		namespace := ref.Namespace
+
+		if namespace == "" {
+			namespace = obj.Metadata.Namespace
+		}
+
+		hasTLS, isKnown := tlsGateways[namespace+"/"+ref.Name]
+
+		if !isKnown || hasTLS {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isK8sTLSProtocol returns true if gateway listener protocol uses TLS
+func isK8sTLSProtocol(protocol string) bool {
+	return protocol == "HTTPS" || protocol == "TLS"
+}
+
+// getK8sTags returns tags with info about object
+func getK8sTags(obj *k8sObject) map[string]string {
+	tags := map[string]string{
+		"resource": obj.Kind + "/" + obj.Metadata.Name,
+	}
+
+	if obj.Metadata.Namespace != "" {
+		tags["namespace"] = obj.Metadata.Namespace
+	}
+
+	return tags
+}
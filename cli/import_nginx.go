@@ -0,0 +1,273 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NGINX_MAX_INCLUDE_DEPTH is maximum depth of nested include directives
+const NGINX_MAX_INCLUDE_DEPTH = 8
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// confToken is token from configuration file
+type confToken struct {
+	Value string
+	Line  int
+	File  string // Source file (used only by nginx importer)
+}
+
+// nginxServer contains info about nginx server block
+type nginxServer struct {
+	Names []confToken
+	TLS   bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// importNginxHosts extracts server names of TLS-enabled server blocks from
+// nginx configuration
+//
+// Files included with include directive are processed too, so only enabled
+// virtual hosts are imported when main configuration file (nginx.conf) is used.
+func importNginxHosts(data []byte, file string) ([]*hostEntry, error) {
+	var result []*hostEntry
+	var blocks []string
+	var servers []*nginxServer
+	var statement []confToken
+
+	tokens := expandNginxIncludes(
+		tokenizeNginxConfig(string(data)), file, filepath.Dir(file), 0,
+	)
+
+	for _, token := range tokens {
+		switch token.Value {
+		case "{":
+			var name string
+
+			if len(statement) != 0 {
+				name = statement[0].Value
+			}
+
+			blocks = append(blocks, name)
+
+			if name == "server" {
+				servers = append(servers, &nginxServer{})
+			}
+
+			statement = nil
+
+		case "}":
+			if len(blocks) == 0 {
+				continue
+			}
+
+			if blocks[len(blocks)-1] == "server" && len(servers) != 0 {
+				server := servers[len(servers)-1]
+				servers = servers[:len(servers)-1]
+
+				if server.TLS {
+					for _, name := range server.Names {
+						result = append(result, newSourceEntry(name.Value, name.File, name.Line))
+					}
+				}
+			}
+
+			blocks = blocks[:len(blocks)-1]
+			statement = nil
+
+		case ";":
+			if len(statement) != 0 && len(blocks) != 0 &&
+				blocks[len(blocks)-1] == "server" && len(servers) != 0 {
+				applyNginxDirective(servers[len(servers)-1], statement)
+			}
+
+			statement = nil
+
+		default:
+			statement = append(statement, token)
+		}
+	}
+
+	return result, nil
+}
+
+// applyNginxDirective applies server block directive to server info
+func applyNginxDirective(server *nginxServer, statement []confToken) {
+	args := statement[1:]
+
+	switch statement[0].Value {
+	case "listen":
+		for _, arg := range args {
+			if arg.Value == "ssl" || arg.Value == "quic" {
+				server.TLS = true
+			}
+		}
+
+	case "ssl":
+		if len(args) != 0 && args[0].Value == "on" {
+			server.TLS = true
+		}
+
+	case "server_name":
+		for _, arg := range args {
+			name := strings.TrimPrefix(arg.Value, ".")
+
+			switch {
+			case name == "", name == "_",
+				strings.HasPrefix(name, "~"),
+				strings.HasPrefix(name, "$"):
+				continue
+			}
+
+			server.Names = append(server.Names, confToken{Value: name, Line: arg.Line, File: arg.File})
+		}
+	}
+}
+
+// expandNginxIncludes sets source file for all tokens and replaces include
+// directives with tokens from included files
+//
+// Relative paths in include directives are resolved from directory of main
+// configuration file, the same way as nginx does it.
+func expandNginxIncludes(tokens []confToken, file, baseDir string, depth int) []confToken {
+	var result []confToken
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		token.File = file
+
+		isStatementStart := i == 0 || tokens[i-1].Value == "{" ||
+			tokens[i-1].Value == "}" || tokens[i-1].Value == ";"
+
+		if token.Value != "include" || !isStatementStart ||
+			i+2 >= len(tokens) || tokens[i+2].Value != ";" {
+			result = append(result, token)
+			continue
+		}
+
+		if depth < NGINX_MAX_INCLUDE_DEPTH {
+			result = append(result, readNginxInclude(tokens[i+1].Value, baseDir, depth+1)...)
+		}
+
+		i += 2
+	}
+
+	return result
+}
+
+// readNginxInclude reads and tokenizes all files matching include pattern
+//
+// If relative pattern doesn't match any file, it is also resolved from parent
+// directory, because files from sites-enabled or conf.d directories usually
+// include files relative to main configuration directory.
+func readNginxInclude(pattern, baseDir string, depth int) []confToken {
+	var result []confToken
+	var files []string
+
+	if filepath.IsAbs(pattern) {
+		files, _ = filepath.Glob(pattern)
+	} else {
+		files, _ = filepath.Glob(filepath.Join(baseDir, pattern))
+
+		if len(files) == 0 {
+			files, _ = filepath.Glob(filepath.Join(filepath.Dir(baseDir), pattern))
+		}
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+
+		if err != nil {
+			continue
+		}
+
+		result = append(result, expandNginxIncludes(
+			tokenizeNginxConfig(string(data)), file, baseDir, depth,
+		)...)
+	}
+
+	return result
+}
+
+// tokenizeNginxConfig splits nginx configuration to tokens
+func tokenizeNginxConfig(data string) []confToken {
+	var result []confToken
+	var word strings.Builder
+
+	line, wordLine := 1, 1
+
+	flush := func() {
+		if word.Len() != 0 {
+			result = append(result, confToken{Value: word.String(), Line: wordLine})
+			word.Reset()
+		}
+	}
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		switch c {
+		case '\n', ' ', '\t', '\r':
+			flush()
+
+			if c == '\n' {
+				line++
+			}
+
+		case '#':
+			if word.Len() != 0 {
+				word.WriteByte(c)
+				continue
+			}
+
+			for i < len(data)-1 && data[i+1] != '\n' {
+				i++
+			}
+
+		case '{', '}', ';':
+			flush()
+			result = append(result, confToken{Value: string(c), Line: line})
+
+		case '"', '\'':
+			flush()
+			wordLine = line
+
+			for i++; i < len(data) && data[i] != c; i++ {
+				if data[i] == '\\' && i < len(data)-1 {
+					i++
+				}
+
+				if data[i] == '\n' {
+					line++
+				}
+
+				word.WriteByte(data[i])
+			}
+
+			result = append(result, confToken{Value: word.String(), Line: wordLine})
+			word.Reset()
+
+		default:
+			if word.Len() == 0 {
+				wordLine = line
+			}
+
+			word.WriteByte(c)
+		}
+	}
+
+	flush()
+
+	return result
+}
@@ -0,0 +1,279 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const TF_MODE_MANAGED = "managed"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// tfDocument contains fields of "terraform show -json" output (state or plan)
+// and raw state file used for hosts discovery
+type tfDocument struct {
+	// terraform show -json
+	Values *tfValues `json:"values"`
+
+	// terraform show -json <plan>
+	PlannedValues *tfValues `json:"planned_values"`
+
+	// terraform.tfstate
+	Resources []*tfStateResource `json:"resources"`
+}
+
+// tfValues contains values of all resources
+type tfValues struct {
+	RootModule *tfModule `json:"root_module"`
+}
+
+// tfModule contains module resources and child modules
+type tfModule struct {
+	Resources    []*tfResource `json:"resources"`
+	ChildModules []*tfModule   `json:"child_modules"`
+}
+
+// tfResource contains resource info from "terraform show -json" output
+type tfResource struct {
+	Address string         `json:"address"`
+	Mode    string         `json:"mode"`
+	Type    string         `json:"type"`
+	Values  map[string]any `json:"values"`
+}
+
+// tfStateResource contains resource info from state file
+type tfStateResource struct {
+	Module    string `json:"module"`
+	Mode      string `json:"mode"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Instances []struct {
+		IndexKey   any            `json:"index_key"`
+		Attributes map[string]any `json:"attributes"`
+	} `json:"instances"`
+}
+
+// tfExtractor is function for extracting host names from resource attributes
+type tfExtractor func(attrs map[string]any) []string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// tfExtractors is map resource type → extractor
+var tfExtractors = map[string]tfExtractor{
+	// DNS records
+	"aws_route53_record":       getTFDNSRecordHosts("fqdn", "name"),
+	"cloudflare_record":        getTFDNSRecordHosts("hostname", "name"),
+	"cloudflare_dns_record":    getTFDNSRecordHosts("name"),
+	"google_dns_record_set":    getTFDNSRecordHosts("name"),
+	"digitalocean_record":      getTFDNSRecordHosts("fqdn"),
+	"azurerm_dns_a_record":     getTFAttrHosts("fqdn"),
+	"azurerm_dns_aaaa_record":  getTFAttrHosts("fqdn"),
+	"azurerm_dns_cname_record": getTFAttrHosts("fqdn"),
+
+	// Load balancers and CDN
+	"aws_lb_listener_rule":                getTFAttrHosts("condition.host_header.values"),
+	"aws_alb_listener_rule":               getTFAttrHosts("condition.host_header.values"),
+	"aws_cloudfront_distribution":         getTFAttrHosts("aliases"),
+	"google_compute_url_map":              getTFAttrHosts("host_rule.hosts"),
+	"azurerm_application_gateway":         getTFAppGatewayHosts,
+	"azurerm_cdn_endpoint_custom_domain":  getTFAttrHosts("host_name"),
+	"azurerm_cdn_frontdoor_custom_domain": getTFAttrHosts("host_name"),
+	"fastly_service_vcl":                  getTFAttrHosts("domain.name"),
+
+	// Certificates
+	"aws_acm_certificate":                    getTFAttrHosts("domain_name", "subject_alternative_names"),
+	"acme_certificate":                       getTFAttrHosts("common_name", "subject_alternative_names"),
+	"google_compute_managed_ssl_certificate": getTFAttrHosts("managed.domains"),
+	"google_certificate_manager_certificate": getTFAttrHosts("managed.domains"),
+	"cloudflare_certificate_pack":            getTFAttrHosts("hosts"),
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// importTerraformHosts extracts TLS hosts from "terraform show -json" output
+// or state file
+func importTerraformHosts(data []byte, file string) ([]*hostEntry, error) {
+	doc := &tfDocument{}
+	err := json.Unmarshal(data, doc)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*tfResource
+
+	switch {
+	case doc.Values != nil:
+		resources = flattenTFModule(doc.Values.RootModule)
+	case doc.PlannedValues != nil:
+		resources = flattenTFModule(doc.PlannedValues.RootModule)
+	default:
+		resources = convertTFStateResources(doc.Resources)
+	}
+
+	var result []*hostEntry
+
+	for _, res := range resources {
+		extractor := tfExtractors[res.Type]
+
+		if res.Mode != TF_MODE_MANAGED || extractor == nil {
+			continue
+		}
+
+		for _, host := range extractor(res.Values) {
+			result = append(result, &hostEntry{
+				Host: host,
+				Tags: map[string]string{"source": file, "resource": res.Address},
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// flattenTFModule returns resources from module and all child modules
+func flattenTFModule(module *tfModule) []*tfResource {
+	if module == nil {
+		return nil
+	}
+
+	result := module.Resources
+
+	for _, child := range module.ChildModules {
+		result = append(result, flattenTFModule(child)...)
+	}
+
+	return result
+}
+
+// convertTFStateResources converts resource instances from state file to
+// resources
+func convertTFStateResources(resources []*tfStateResource) []*tfResource {
+	var result []*tfResource
+
+	for _, res := range resources {
+		address := res.Type + "." + res.Name
+
+		if res.Mode != TF_MODE_MANAGED {
+			address = res.Mode + "." + address
+		}
+
+		if res.Module != "" {
+			address = res.Module + "." + address
+		}
+
+		for _, instance := range res.Instances {
+			instAddress := address
+
+			switch key := instance.IndexKey.(type) {
+			case string:
+				instAddress += fmt.Sprintf("[%q]", key)
+			case float64:
+				instAddress += fmt.Sprintf("[%d]", int(key))
+			}
+
+			result = append(result, &tfResource{
+				Address: instAddress,
+				Mode:    res.Mode,
+				Type:    res.Type,
+				Values:  instance.Attributes,
+			})
+		}
+	}
+
+	return result
+}
+
+// getTFAttrHosts returns extractor for host names from given attribute paths
+func getTFAttrHosts(paths ...string) tfExtractor {
+	return func(attrs map[string]any) []string {
+		var result []string
+
+		for _, path := range paths {
+			result = append(result, getTFStrings(attrs, strings.Split(path, "."))...)
+		}
+
+		return result
+	}
+}
+
+// getTFDNSRecordHosts returns extractor for names of A, AAAA and CNAME records
+//
+// Attributes are checked in given order and the first one with fully qualified
+// name is used.
+func getTFDNSRecordHosts(attrs ...string) tfExtractor {
+	return func(values map[string]any) []string {
+		recordType, _ := values["type"].(string)
+
+		if !zoneRecordTypes[strings.ToUpper(recordType)] {
+			return nil
+		}
+
+		for _, attr := range attrs {
+			name, _ := values[attr].(string)
+
+			if strings.Contains(name, ".") {
+				return []string{name}
+			}
+		}
+
+		return nil
+	}
+}
+
+// getTFAppGatewayHosts returns host names of HTTPS listeners of Azure
+// application gateway
+func getTFAppGatewayHosts(attrs map[string]any) []string {
+	var result []string
+
+	listeners, _ := attrs["http_listener"].([]any)
+
+	for _, listener := range listeners {
+		listenerAttrs, _ := listener.(map[string]any)
+		protocol, _ := listenerAttrs["protocol"].(string)
+
+		if strings.EqualFold(protocol, "https") {
+			result = append(result, getTFStrings(listenerAttrs, []string{"host_name"})...)
+			result = append(result, getTFStrings(listenerAttrs, []string{"host_names"})...)
+		}
+	}
+
+	return result
+}
+
+// getTFStrings returns all non-empty strings from attribute with given path
+//
+// Nested blocks are represented as lists of objects, so all elements of lists
+// are visited.
+func getTFStrings(value any, path []string) []string {
+	var result []string
+
+	switch v := value.(type) {
+	case string:
+		if len(path) == 0 && v != "" {
+			result = append(result, v)
+		}
+
+	case []any:
+		for _, item := range v {
+			result = append(result, getTFStrings(item, path)...)
+		}
+
+	case map[string]any:
+		if len(path) != 0 {
+			result = getTFStrings(v[path[0]], path[1:])
+		}
+	}
+
+	return result
+}
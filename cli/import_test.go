@@ -0,0 +1,294 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// importTestCase is test case for importer
+type importTestCase struct {
+	Name     string
+	Data     string
+	Expected []string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestNginxImporter(t *testing.T) {
+	runImportTests(t, importNginxHosts, "nginx.conf", []importTestCase{
+		{
+			Name: "listen with ssl",
+			Data: `server {
+    listen 443 ssl;
+    server_name domain.com www.domain.com;
+}`,
+			Expected: []string{
+				"domain.com source=nginx.conf:3",
+				"www.domain.com source=nginx.conf:3",
+			},
+		},
+		{
+			Name: "plain HTTP server",
+			Data: `server {
+    listen 80;
+    server_name domain.com;
+}`,
+		},
+		{
+			Name: "ssl directive and quic",
+			Data: `http {
+    server {
+        ssl on;
+        server_name "a.domain.com";
+    }
+    server {
+        listen 443 quic;
+        server_name b.domain.com; # comment
+    }
+}`,
+			Expected: []string{
+				"a.domain.com source=nginx.conf:4",
+				"b.domain.com source=nginx.conf:8",
+			},
+		},
+		{
+			Name: "special names",
+			Data: `server {
+    listen 443 ssl;
+    server_name _ .domain.com ~^www\d+$ $hostname;
+}`,
+			Expected: []string{"domain.com source=nginx.conf:3"},
+		},
+		{
+			Name: "nested blocks",
+			Data: `server {
+    listen 443 ssl;
+    location / {
+        proxy_pass http://backend;
+    }
+    server_name domain.com;
+}`,
+			Expected: []string{"domain.com source=nginx.conf:6"},
+		},
+	})
+}
+
+func TestNginxImporterIncludes(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(dir, "nginx.conf"), `http {
+    include sites-enabled/*.conf;
+}`)
+
+	writeTestFile(t, filepath.Join(dir, "snippets", "tls.conf"), `listen 443 ssl;`)
+
+	writeTestFile(t, filepath.Join(dir, "sites-enabled", "site.conf"), `server {
+    include snippets/tls.conf;
+    server_name domain.com;
+}`)
+
+	file := filepath.Join(dir, "nginx.conf")
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := importNginxHosts(data, file)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"domain.com source=" + filepath.Join(dir, "sites-enabled", "site.conf") + ":3",
+	}
+
+	if result := formatTestEntries(entries); !slices.Equal(result, expected) {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestApacheImporter(t *testing.T) {
+	runImportTests(t, importApacheHosts, "httpd.conf", []importTestCase{
+		{
+			Name: "virtual host on port 443",
+			Data: `<VirtualHost *:443>
+    ServerName domain.com
+    ServerAlias www.domain.com # comment
+</VirtualHost>`,
+			Expected: []string{
+				"domain.com source=httpd.conf:2",
+				"www.domain.com source=httpd.conf:3",
+			},
+		},
+		{
+			Name: "virtual host on port 80",
+			Data: `<VirtualHost *:80>
+    ServerName domain.com
+</VirtualHost>`,
+		},
+		{
+			Name: "SSLEngine directive",
+			Data: `<VirtualHost *:8443>
+    SSLEngine on
+    ServerName a.domain.com
+</VirtualHost>
+<VirtualHost *:443>
+    SSLEngine off
+    ServerName b.domain.com
+</VirtualHost>`,
+			Expected: []string{"a.domain.com source=httpd.conf:3"},
+		},
+		{
+			Name: "names outside virtual host",
+			Data: `ServerName domain.com
+# <VirtualHost *:443>`,
+		},
+	})
+}
+
+func TestK8sImporter(t *testing.T) {
+	runImportTests(t, importK8sHosts, "manifest.yaml", []importTestCase{
+		{
+			Name: "ingress",
+			Data: `kind: Ingress
+metadata:
+  name: web
+  namespace: prod
+spec:
+  tls:
+    - hosts: [domain.com, www.domain.com]
+  rules:
+    - host: plain.domain.com`,
+			Expected: []string{
+				"domain.com namespace=prod resource=Ingress/web",
+				"www.domain.com namespace=prod resource=Ingress/web",
+			},
+		},
+		{
+			Name: "gateway and routes",
+			Data: `kind: Gateway
+metadata:
+  name: gw
+  namespace: infra
+spec:
+  listeners:
+    - hostname: gw.domain.com
+      protocol: HTTPS
+    - hostname: http.domain.com
+      protocol: HTTP
+---
+kind: Gateway
+metadata:
+  name: plain
+  namespace: infra
+spec:
+  listeners:
+    - protocol: HTTP
+---
+kind: HTTPRoute
+metadata:
+  name: tls-route
+  namespace: app
+spec:
+  parentRefs:
+    - name: gw
+      namespace: infra
+  hostnames: [app.domain.com]
+---
+kind: HTTPRoute
+metadata:
+  name: plain-route
+  namespace: app
+spec:
+  parentRefs:
+    - name: plain
+      namespace: infra
+  hostnames: [plain.domain.com]`,
+			Expected: []string{
+				"gw.domain.com namespace=infra resource=Gateway/gw",
+				"app.domain.com namespace=app resource=HTTPRoute/tls-route",
+			},
+		},
+		{
+			Name: "list with certificate",
+			Data: `kind: List
+items:
+  - kind: Certificate
+    metadata:
+      name: cert
+    spec:
+      commonName: domain.com
+      dnsNames: [api.domain.com]`,
+			Expected: []string{
+				"domain.com resource=Certificate/cert",
+				"api.domain.com resource=Certificate/cert",
+			},
+		},
+	})
+
+	_, err := importK8sHosts([]byte("kind: [Ingress"), "manifest.yaml")
+
+	if err == nil {
+		t.Error("Expected error for malformed manifest")
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// runImportTests runs importer test cases
+func runImportTests(t *testing.T, imp importer, file string, cases []importTestCase) {
+	t.Helper()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			entries, err := imp([]byte(tc.Data), file)
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result := formatTestEntries(entries); !slices.Equal(result, tc.Expected) {
+				t.Errorf("Expected %q, got %q", tc.Expected, result)
+			}
+		})
+	}
+}
+
+// formatTestEntries returns host entries as list of strings
+func formatTestEntries(entries []*hostEntry) []string {
+	var result []string
+
+	for _, entry := range entries {
+		result = append(result, entry.Host+" "+formatTags(entry.Tags))
+	}
+
+	return result
+}
+
+// writeTestFile creates file with given data
+func writeTestFile(t *testing.T, file, data string) {
+	t.Helper()
+
+	err := os.MkdirAll(filepath.Dir(file), 0755)
+
+	if err == nil {
+		err = os.WriteFile(file, []byte(data), 0644)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,154 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// zoneRecordTypes contains types of records used for hosts discovery
+var zoneRecordTypes = map[string]bool{"A": true, "AAAA": true, "CNAME": true}
+
+// zoneClasses contains DNS classes
+var zoneClasses = map[string]bool{"IN": true, "CH": true, "HS": true, "CS": true}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// importZoneHosts extracts names of A, AAAA and CNAME records from BIND zone file
+//
+// If zone file doesn't contain $ORIGIN directive, origin is guessed from file
+// name (example.com.zone, db.example.com).
+func importZoneHosts(data []byte, file string) ([]*hostEntry, error) {
+	var result []*hostEntry
+	var owner string
+	var parens int
+
+	origin := getZoneOriginFromFile(file)
+
+	for index, line := range strings.Split(string(data), "\n") {
+		line = stripZoneComment(line)
+
+		if parens > 0 {
+			parens += strings.Count(line, "(") - strings.Count(line, ")")
+			continue
+		}
+
+		fields := strings.Fields(line)
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(fields[0], "$") {
+			if strings.EqualFold(fields[0], "$ORIGIN") && len(fields) > 1 {
+				origin = strings.TrimSuffix(strings.ToLower(fields[1]), ".")
+			}
+
+			continue
+		}
+
+		// Record without owner uses owner of previous record
+		if line[0] != ' ' && line[0] != '\t' {
+			owner, fields = fields[0], fields[1:]
+		}
+
+		parens += strings.Count(line, "(") - strings.Count(line, ")")
+		recordType := getZoneRecordType(fields)
+
+		if !zoneRecordTypes[recordType] {
+			continue
+		}
+
+		name := getZoneRecordName(owner, origin)
+
+		if name != "" {
+			entry := newSourceEntry(name, file, index+1)
+			entry.Tags["record"] = recordType
+			result = append(result, entry)
+		}
+	}
+
+	return result, nil
+}
+
+// getZoneRecordType returns type of record from record fields without owner
+func getZoneRecordType(fields []string) string {
+	for _, field := range fields {
+		field = strings.ToUpper(field)
+
+		if zoneClasses[field] || isZoneTTL(field) {
+			continue
+		}
+
+		return field
+	}
+
+	return ""
+}
+
+// getZoneRecordName returns fully qualified name of record owner
+func getZoneRecordName(owner, origin string) string {
+	switch {
+	case owner == "",
+		strings.HasPrefix(owner, "*"),
+		strings.HasPrefix(owner, "_"):
+		return ""
+	case owner == "@":
+		return origin
+	case strings.HasSuffix(owner, "."):
+		return strings.TrimSuffix(owner, ".")
+	case origin == "":
+		return ""
+	}
+
+	return owner + "." + origin
+}
+
+// getZoneOriginFromFile guesses zone origin from file name
+func getZoneOriginFromFile(file string) string {
+	name := strings.ToLower(filepath.Base(file))
+	name = strings.TrimPrefix(name, "db.")
+	name = strings.TrimSuffix(name, ".zone")
+	name = strings.TrimSuffix(name, ".db")
+
+	if !strings.Contains(name, ".") {
+		return ""
+	}
+
+	return name
+}
+
+// isZoneTTL returns true if given field is TTL value (86400, 1h, 1d12h…)
+func isZoneTTL(field string) bool {
+	if field == "" || field[0] < '0' || field[0] > '9' {
+		return false
+	}
+
+	return strings.Trim(field, "0123456789SMHDW") == ""
+}
+
+// stripZoneComment removes comment from zone file line
+func stripZoneComment(line string) string {
+	var quoted bool
+
+	for i, c := range line {
+		switch c {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				return strings.TrimRight(line[:i], " \t\r")
+			}
+		}
+	}
+
+	return strings.TrimRight(line, " \t\r")
+}
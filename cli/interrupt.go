@@ -0,0 +1,134 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/signal"
+	"github.com/essentialkaos/ek/v13/terminal"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RESUME_FILE is default name of file with list of hosts which weren't checked
+// due to interrupt
+const RESUME_FILE = "sslcli-resume.txt"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// interrupts is number of received SIGINT/SIGTERM signals
+var interrupts atomic.Int32
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// trackInterrupts starts tracking SIGINT and SIGTERM signals
+func trackInterrupts() {
+	signal.Handlers{
+		signal.INT:  interruptHandler,
+		signal.TERM: interruptHandler,
+	}.Track()
+}
+
+// interruptHandler is SIGINT/SIGTERM signal handler
+//
+// First signal stops starting new checks, second one cancels check in progress,
+// third one terminates the app immediately.
+func interruptHandler() {
+	count := interrupts.Add(1)
+
+	if options.GetB(OPT_QUIET) && count < 3 {
+		return
+	}
+
+	switch count {
+	case 1:
+		terminal.Warn("\nInterrupted, waiting for current check to finish (press Ctrl+C again to cancel it)…")
+	case 2:
+		terminal.Warn("\nCancelling current check…")
+	default:
+		os.Exit(EC_CANCELLED)
+	}
+}
+
+// isInterrupted returns true if app received interrupt signal
+func isInterrupted() bool {
+	return interrupts.Load() > 0
+}
+
+// isCancelled returns true if check in progress must be cancelled
+func isCancelled() bool {
+	return interrupts.Load() > 1
+}
+
+// getCancelledCheckInfo returns info for host which wasn't checked due to interrupt
+func getCancelledCheckInfo(host string) *HostCheckInfo {
+	return &HostCheckInfo{
+		Host:         host,
+		LowestGrade:  "Err",
+		HighestGrade: "Err",
+		Endpoints:    make([]*EndpointCheckInfo, 0),
+		Error:        newCancelledError(),
+		Tags:         hostTags[host],
+	}
+}
+
+// saveResumeState saves list of unchecked hosts to file
+func saveResumeState(hosts []string) {
+	if len(hosts) == 0 {
+		return
+	}
+
+	file := options.GetS(OPT_RESUME_FILE)
+	err := os.WriteFile(file, []byte(strings.Join(hosts, "\n")+"\n"), 0644)
+
+	if options.GetB(OPT_QUIET) {
+		return
+	}
+
+	if err != nil {
+		terminal.Warn("Can't save list of unchecked hosts: %v", err)
+		return
+	}
+
+	terminal.Warn(
+		"List of unchecked hosts saved to %s, use it as hosts list to continue checking",
+		file,
+	)
+}
+
+// printCancelledHosts prints list of hosts which weren't checked due to interrupt
+func printCancelledHosts(checksInfo []*HostCheckInfo) {
+	if options.GetB(OPT_QUIET) || options.GetB(OPT_FORMAT) {
+		return
+	}
+
+	var hosts []string
+
+	for _, checkInfo := range checksInfo {
+		if checkInfo.Error != nil && checkInfo.Error.Phase == PHASE_CANCELLED {
+			hosts = append(hosts, checkInfo.Host)
+		}
+	}
+
+	if len(hosts) == 0 {
+		return
+	}
+
+	fmtc.Printfn("\n{*}Unchecked hosts {s-}(%d){!}\n", len(hosts))
+
+	for _, host := range hosts {
+		fmtc.Printfn("{*}%s{!} {s-}→{!} {y}Check cancelled by user{!}", host)
+	}
+
+	fmtc.NewLine()
+}
@@ -0,0 +1,339 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/pluralize"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	CERT_TYPE_LEAF         = "leaf"
+	CERT_TYPE_INTERMEDIATE = "intermediate"
+	CERT_TYPE_ROOT         = "root"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// InventoryCert contains info about certificate and endpoints serving it
+type InventoryCert struct {
+	SHA256Hash string               `json:"sha256Hash"`
+	Type       string               `json:"type"`
+	Subject    string               `json:"subject"`
+	AltNames   []string             `json:"altNames"`
+	Issuer     string               `json:"issuer"`
+	KeyAlg     string               `json:"keyAlg"`
+	KeySize    int                  `json:"keySize"`
+	SigAlg     string               `json:"sigAlg"`
+	NotAfter   string               `json:"notAfter"`
+	DaysLeft   int64                `json:"daysLeft"`
+	Endpoints  []*InventoryEndpoint `json:"endpoints"`
+}
+
+// InventoryEndpoint contains info about endpoint serving certificate
+type InventoryEndpoint struct {
+	Host      string `json:"host"`
+	IPAddress string `json:"ipAddress"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// renderInventory renders inventory of all certificates served by checked hosts
+func renderInventory(checksInfo []*HostCheckInfo) {
+	inventory := getInventory(checksInfo)
+
+	switch options.GetS(OPT_FORMAT) {
+	case FORMAT_JSON:
+		encodeInventoryAsJSON(inventory)
+	case FORMAT_YAML:
+		encodeInventoryAsYAML(inventory)
+	case FORMAT_CSV:
+		encodeInventoryAsCSV(inventory, ',')
+	case FORMAT_TSV:
+		encodeInventoryAsCSV(inventory, '\t')
+	default:
+		printInventory(inventory)
+	}
+}
+
+// getInventory collects leaf and intermediate certificates from all endpoints
+// and de-duplicates them by SHA256 hash
+func getInventory(checksInfo []*HostCheckInfo) []*InventoryCert {
+	var result []*InventoryCert
+
+	certsIndex := make(map[string]*InventoryCert)
+
+	for _, checkInfo := range checksInfo {
+		if checkInfo.info == nil {
+			continue
+		}
+
+		for _, endpoint := range checkInfo.info.Endpoints {
+			if endpoint.Details == nil {
+				continue
+			}
+
+			for _, chain := range endpoint.Details.CertChains {
+				for index, certID := range chain.CertIDs {
+					cert := findCertByID(checkInfo.info.Certs, certID)
+
+					// Skip unknown certificates and roots sent by server
+					if cert == nil || cert.SHA256Hash == "" ||
+						(index != 0 && cert.Subject == cert.IssuerSubject) {
+						continue
+					}
+
+					invCert := certsIndex[cert.SHA256Hash]
+
+					if invCert == nil {
+						invCert = newInventoryCert(cert, index == 0)
+						certsIndex[cert.SHA256Hash] = invCert
+						result = append(result, invCert)
+					}
+
+					invCert.addEndpoint(checkInfo.Host, endpoint.IPAddress)
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b *InventoryCert) int {
+		if a.Type != b.Type {
+			return cmp.Compare(b.Type, a.Type) // leaf → intermediate
+		}
+
+		return cmp.Compare(a.DaysLeft, b.DaysLeft)
+	})
+
+	return result
+}
+
+// newInventoryCert creates inventory record for certificate
+func newInventoryCert(cert *sslscan.Cert, isLeaf bool) *InventoryCert {
+	certType := CERT_TYPE_INTERMEDIATE
+
+	if isLeaf {
+		certType = CERT_TYPE_LEAF
+	}
+
+	return &InventoryCert{
+		SHA256Hash: cert.SHA256Hash,
+		Type:       certType,
+		Subject:    extractSubject(cert.Subject),
+		AltNames:   cert.AltNames,
+		Issuer:     extractSubject(cert.IssuerSubject),
+		KeyAlg:     cert.KeyAlg,
+		KeySize:    cert.KeySize,
+		SigAlg:     cert.SigAlg,
+		NotAfter:   time.Unix(cert.NotAfter/1000, 0).UTC().Format(time.RFC3339),
+		DaysLeft:   getValidDays(cert),
+	}
+}
+
+// addEndpoint adds endpoint to the list of endpoints serving certificate
+func (c *InventoryCert) addEndpoint(host, ip string) {
+	for _, endpoint := range c.Endpoints {
+		if endpoint.Host == host && endpoint.IPAddress == ip {
+			return
+		}
+	}
+
+	c.Endpoints = append(c.Endpoints, &InventoryEndpoint{host, ip})
+}
+
+// getHostNames returns list of unique hosts serving certificate
+func (c *InventoryCert) getHostNames() []string {
+	var result []string
+
+	for _, endpoint := range c.Endpoints {
+		if !slices.Contains(result, endpoint.Host) {
+			result = append(result, endpoint.Host)
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// printInventory prints inventory to console
+func printInventory(inventory []*InventoryCert) {
+	fmtc.NewLine()
+
+	printCategoryHeader(os.Stdout, "Certificate Inventory")
+
+	if len(inventory) == 0 {
+		fmtc.Println("\n {s}No certificates found{!}\n")
+		fmtutil.Separator(true)
+		return
+	}
+
+	for _, cert := range inventory {
+		fmtc.Printfn(" %-24s {s}|{!} {*}%s{!} {s-}(%s){!}", "Subject", cert.Subject, cert.Type)
+		fmtc.Printfn(" %-24s {s}|{!} {s-}Fingerprint: %s{!}", "", cert.SHA256Hash)
+
+		if len(cert.AltNames) != 0 {
+			fmtc.Printfn(" %-24s {s}|{!} %s", "Alternative names", strings.Join(cert.AltNames, " "))
+		}
+
+		fmtc.Printfn(" %-24s {s}|{!} %s", "Issuer", cert.Issuer)
+		fmtc.Printfn(" %-24s {s}|{!} %s %d bits", "Key", cert.KeyAlg, cert.KeySize)
+		fmtc.Printfn(" %-24s {s}|{!} %s", "Signature algorithm", cert.SigAlg)
+
+		notAfter, _ := time.Parse(time.RFC3339, cert.NotAfter)
+
+		if cert.DaysLeft < 0 {
+			fmtc.Printfn(
+				" %-24s {s}|{!} {r}%s (EXPIRED){!}", "Valid until",
+				timeutil.Format(notAfter.Local(), "%Y/%m/%d %H:%M:%S"),
+			)
+		} else {
+			fmtc.Printfn(
+				" %-24s {s}|{!} %s {s-}(expires in %s %s){!}", "Valid until",
+				timeutil.Format(notAfter.Local(), "%Y/%m/%d %H:%M:%S"),
+				fmtutil.PrettyNum(cert.DaysLeft),
+				pluralize.Pluralize(int(cert.DaysLeft), "day", "days"),
+			)
+		}
+
+		for index, endpoint := range cert.Endpoints {
+			name := "Served by"
+
+			if index != 0 {
+				name = ""
+			}
+
+			fmtc.Printfn(" %-24s {s}|{!} %s {s-}(%s){!}", name, endpoint.Host, endpoint.IPAddress)
+		}
+
+		fmtutil.Separator(true)
+	}
+
+	fmtc.Printfn(
+		"\n {s}%s %s served by %s %s{!}\n",
+		fmtutil.PrettyNum(len(inventory)),
+		pluralize.Pluralize(len(inventory), "certificate", "certificates"),
+		fmtutil.PrettyNum(countInventoryHosts(inventory)),
+		pluralize.Pluralize(countInventoryHosts(inventory), "host", "hosts"),
+	)
+}
+
+// encodeInventoryAsJSON prints inventory in JSON format
+func encodeInventoryAsJSON(inventory []*InventoryCert) {
+	if inventory == nil {
+		inventory = []*InventoryCert{}
+	}
+
+	jsonData, err := json.MarshalIndent(inventory, "", "  ")
+
+	if err != nil {
+		fmt.Println("[]")
+		os.Exit(1)
+	}
+
+	fmt.Println(string(jsonData))
+}
+
+// encodeInventoryAsYAML prints inventory in YAML format
+func encodeInventoryAsYAML(inventory []*InventoryCert) {
+	fmt.Println("---")
+	fmt.Println("certificates:")
+
+	for _, cert := range inventory {
+		fmt.Println("  -")
+		fmt.Printf("    sha256Hash: %s\n", cert.SHA256Hash)
+		fmt.Printf("    type: %s\n", cert.Type)
+		fmt.Printf("    subject: %q\n", cert.Subject)
+
+		if len(cert.AltNames) != 0 {
+			fmt.Println("    altNames:")
+
+			for _, name := range cert.AltNames {
+				fmt.Printf("      - %q\n", name)
+			}
+		}
+
+		fmt.Printf("    issuer: %q\n", cert.Issuer)
+		fmt.Printf("    keyAlg: %s\n", cert.KeyAlg)
+		fmt.Printf("    keySize: %d\n", cert.KeySize)
+		fmt.Printf("    sigAlg: %s\n", cert.SigAlg)
+		fmt.Printf("    notAfter: %q\n", cert.NotAfter)
+		fmt.Printf("    daysLeft: %d\n", cert.DaysLeft)
+		fmt.Println("    endpoints:")
+
+		for _, endpoint := range cert.Endpoints {
+			fmt.Println("      -")
+			fmt.Printf("        host: %s\n", endpoint.Host)
+			fmt.Printf("        ipAddress: \"%s\"\n", endpoint.IPAddress)
+		}
+	}
+}
+
+// encodeInventoryAsCSV prints inventory in CSV or TSV format
+func encodeInventoryAsCSV(inventory []*InventoryCert, separator rune) {
+	w := csv.NewWriter(os.Stdout)
+	w.Comma = separator
+
+	w.Write([]string{
+		"sha256", "type", "subject", "alt-names", "issuer", "key-alg", "key-size",
+		"sig-alg", "not-after", "days-left", "hosts", "endpoints",
+	})
+
+	for _, cert := range inventory {
+		var endpoints []string
+
+		for _, endpoint := range cert.Endpoints {
+			endpoints = append(endpoints, endpoint.Host+"/"+endpoint.IPAddress)
+		}
+
+		notAfter, _ := time.Parse(time.RFC3339, cert.NotAfter)
+
+		w.Write([]string{
+			cert.SHA256Hash, cert.Type, cert.Subject,
+			strings.Join(cert.AltNames, ", "), cert.Issuer,
+			cert.KeyAlg, fmt.Sprintf("%d", cert.KeySize), cert.SigAlg,
+			timeutil.Format(notAfter, "%Y-%m-%d"), fmt.Sprintf("%d", cert.DaysLeft),
+			strings.Join(cert.getHostNames(), ", "), strings.Join(endpoints, ", "),
+		})
+	}
+
+	w.Flush()
+
+	if w.Error() != nil {
+		os.Exit(1)
+	}
+}
+
+// countInventoryHosts returns number of unique hosts in inventory
+func countInventoryHosts(inventory []*InventoryCert) int {
+	hosts := make(map[string]bool)
+
+	for _, cert := range inventory {
+		for _, host := range cert.getHostNames() {
+			hosts[host] = true
+		}
+	}
+
+	return len(hosts)
+}
@@ -0,0 +1,312 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	MD_BADGE_INSECURE = "`INSECURE`"
+	MD_BADGE_WEAK     = "`WEAK`"
+	MD_BADGE_EXPIRED  = "`EXPIRED`"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// encodeAsMarkdown writes check info in Markdown format
+func encodeAsMarkdown(w io.Writer, checksInfo []*HostCheckInfo) {
+	fmt.Fprintln(w, "# TLS Report")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Host | Lowest Grade | Highest Grade | Endpoints | Certificate Expiry | Failed Checks |")
+	fmt.Fprintln(w, "|------|--------------|---------------|-----------|--------------------|---------------|")
+
+	for _, info := range checksInfo {
+		var endpoints []string
+
+		for _, endpoint := range info.Endpoints {
+			endpoints = append(endpoints, endpoint.IPAddress+" ("+endpoint.Grade+")")
+		}
+
+		failures := mdEscape(strings.Join(getPolicyFailures(info), "<br>"))
+		host := mdEscape(info.Host)
+
+		if len(info.Tags) != 0 {
+			host += "<br><sub>" + mdEscape(formatTags(info.Tags)) + "</sub>"
+		}
+
+		fmt.Fprintf(w,
+			"| %s | %s | %s | %s | %s | %s |\n",
+			host, info.LowestGrade, info.HighestGrade,
+			mdValue(strings.Join(endpoints, "<br>")),
+			mdValue(getMarkdownExpiry(info)), mdValue(failures),
+		)
+	}
+
+	if !options.GetB(OPT_DETAILED) {
+		return
+	}
+
+	for _, info := range checksInfo {
+		printMarkdownHostInfo(w, info)
+	}
+}
+
+// printMarkdownHostInfo prints detailed info about host in Markdown format
+func printMarkdownHostInfo(w io.Writer, checkInfo *HostCheckInfo) {
+	fmt.Fprintf(w, "\n## %s\n", checkInfo.Host)
+
+	info := checkInfo.info
+
+	if info == nil || len(info.Endpoints) == 0 {
+		fmt.Fprintln(w, "\n_No detailed info available_")
+		return
+	}
+
+	printMarkdownCertificateInfo(w, info.Certs)
+
+	for index, endpoint := range info.Endpoints {
+		if endpoint.Details == nil {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n### Endpoint #%d (%s)\n", index+1, endpoint.IPAddress)
+
+		printMarkdownProtocolsInfo(w, endpoint.Details)
+		printMarkdownCipherSuitesInfo(w, endpoint.Details)
+		printMarkdownProtocolDetailsInfo(w, endpoint.Details)
+	}
+}
+
+// printMarkdownCertificateInfo prints info about server certificate in Markdown format
+func printMarkdownCertificateInfo(w io.Writer, certs []*sslscan.Cert) {
+	fmt.Fprintln(w, "\n### Server Key and Certificate")
+	fmt.Fprintln(w)
+
+	if len(certs) == 0 {
+		fmt.Fprintln(w, "_No valid certificates and keys_ "+MD_BADGE_INSECURE)
+		return
+	}
+
+	cert := certs[0]
+
+	validUntil := timeutil.Format(time.Unix(cert.NotAfter/1000, 0), "%Y/%m/%d %H:%M:%S")
+	validDays := getValidDays(cert)
+
+	if validDays < 0 {
+		validUntil += " " + MD_BADGE_EXPIRED
+	} else {
+		validUntil += fmt.Sprintf(" (expires in %d days)", validDays)
+	}
+
+	key := fmt.Sprintf("%s %d bits", cert.KeyAlg, cert.KeySize)
+
+	if isWeakCertKey(cert) {
+		key += " " + MD_BADGE_WEAK
+	}
+
+	sigAlg := cert.SigAlg
+
+	if weakAlgorithms[cert.SigAlg] {
+		sigAlg += " " + MD_BADGE_WEAK
+	}
+
+	trusted := "Yes"
+
+	if cert.Issues != 0 {
+		trusted = "No (" + getCertIssuesDesc(cert.Issues) + ") " + MD_BADGE_INSECURE
+	}
+
+	fmt.Fprintf(w, "- **Subject:** %s\n", mdEscape(extractSubject(cert.Subject)))
+	fmt.Fprintf(w, "  - Fingerprint: `%s`\n", cert.SHA256Hash)
+	fmt.Fprintf(w, "  - Pin: `%s`\n", cert.PINSHA256)
+	fmt.Fprintf(w, "- **Common names:** %s\n", mdEscape(strings.Join(cert.CommonNames, " ")))
+
+	if len(cert.AltNames) != 0 {
+		fmt.Fprintf(w, "- **Alternative names:** %s\n", mdEscape(strings.Join(cert.AltNames, " ")))
+	}
+
+	fmt.Fprintf(w, "- **Valid until:** %s\n", validUntil)
+	fmt.Fprintf(w, "- **Key:** %s\n", key)
+	fmt.Fprintf(w, "- **Issuer:** %s\n", mdEscape(extractSubject(cert.IssuerSubject)))
+	fmt.Fprintf(w, "- **Signature algorithm:** %s\n", sigAlg)
+	fmt.Fprintf(w, "- **Revocation status:** %s\n", getRevocationStatus(cert.RevocationStatus))
+	fmt.Fprintf(w, "- **DNS CAA:** %s\n", printBool(cert.DNSCAA))
+	fmt.Fprintf(w, "- **Trusted:** %s\n", trusted)
+}
+
+// printMarkdownProtocolsInfo prints info about supported protocols in Markdown format
+func printMarkdownProtocolsInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	if len(details.Protocols) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\n#### Protocols")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Protocol | Supported |")
+	fmt.Fprintln(w, "|----------|-----------|")
+
+	supportedProtocols := getProtocols(details.Protocols)
+
+	for _, protocol := range protocolList {
+		status := printBool(supportedProtocols[protocol])
+
+		if supportedProtocols[protocol] {
+			insecure, weak := getProtocolSecurity(protocol)
+
+			switch {
+			case insecure:
+				status += " " + MD_BADGE_INSECURE
+			case weak:
+				status += " " + MD_BADGE_WEAK
+			}
+		}
+
+		fmt.Fprintf(w, "| %s | %s |\n", protocol, status)
+	}
+}
+
+// printMarkdownCipherSuitesInfo prints info about cipher suites in Markdown format
+func printMarkdownCipherSuitesInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	if len(details.Suites) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\n#### Cipher Suites")
+	fmt.Fprintln(w)
+
+	for i := len(details.Suites) - 1; i >= 0; i-- {
+		suites := details.Suites[i]
+
+		if suites.Preference {
+			fmt.Fprintf(w, "- **%s** (suites in server-preferred order)\n\n", protocolsNames[suites.Protocol])
+		} else {
+			fmt.Fprintf(w, "- **%s** (server has no preference)\n\n", protocolsNames[suites.Protocol])
+		}
+
+		fmt.Fprintln(w, "  | Cipher Suite | Strength | Key Exchange |")
+		fmt.Fprintln(w, "  |--------------|----------|--------------|")
+
+		for _, suite := range suites.List {
+			strength := fmt.Sprintf("%d", suite.CipherStrength)
+			insecure, weak := getSuiteSecurity(suite)
+
+			switch {
+			case insecure:
+				strength += " " + MD_BADGE_INSECURE
+			case weak:
+				strength += " " + MD_BADGE_WEAK
+			}
+
+			var kx string
+
+			switch {
+			case suite.KxType == "DH":
+				kx = fmt.Sprintf("DH %d bits", suite.KxStrength)
+			case suite.NamedGroupName != "":
+				kx = fmt.Sprintf("%s %s ~ %d bits RSA", suite.KxType, suite.NamedGroupName, suite.KxStrength)
+			default:
+				kx = "—"
+			}
+
+			fmt.Fprintf(w, "  | `%s` | %s | %s |\n", suite.Name, strength, kx)
+		}
+
+		fmt.Fprintln(w)
+	}
+}
+
+// printMarkdownProtocolDetailsInfo prints endpoint protocol details in Markdown format
+func printMarkdownProtocolDetailsInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	fmt.Fprintln(w, "#### Protocol Details")
+	fmt.Fprintln(w)
+
+	for _, vuln := range getVulnerabilities(details) {
+		if vuln.Vulnerable {
+			fmt.Fprintf(w, "- **%s:** Vulnerable %s\n", vuln.Name, MD_BADGE_INSECURE)
+		} else {
+			fmt.Fprintf(w, "- **%s:** No\n", vuln.Name)
+		}
+	}
+
+	if details.FallbackSCSV {
+		fmt.Fprintln(w, "- **Downgrade attack prevention:** Yes, TLS_FALLBACK_SCSV supported")
+	} else {
+		fmt.Fprintln(w, "- **Downgrade attack prevention:** No, TLS_FALLBACK_SCSV not supported "+MD_BADGE_WEAK)
+	}
+
+	switch {
+	case details.ForwardSecrecy == 0:
+		fmt.Fprintln(w, "- **Forward Secrecy:** No "+MD_BADGE_WEAK)
+	case details.ForwardSecrecy&4 == 4:
+		fmt.Fprintln(w, "- **Forward Secrecy:** Yes (with most browsers)")
+	case details.ForwardSecrecy&2 == 2:
+		fmt.Fprintln(w, "- **Forward Secrecy:** With modern browsers")
+	default:
+		fmt.Fprintln(w, "- **Forward Secrecy:** With some browsers "+MD_BADGE_WEAK)
+	}
+
+	fmt.Fprintf(w, "- **OCSP stapling:** %s\n", printBool(details.OCSPStapling))
+
+	if details.HSTSPolicy != nil && details.HSTSPolicy.Status == sslscan.HSTS_STATUS_PRESENT {
+		fmt.Fprintf(w, "- **Strict Transport Security (HSTS):** Yes (`%s`)\n", details.HSTSPolicy.Header)
+	} else {
+		fmt.Fprintln(w, "- **Strict Transport Security (HSTS):** No")
+	}
+
+	if details.SupportsALPN {
+		fmt.Fprintf(w, "- **ALPN:** Yes (%s)\n", details.ALPNProtocols)
+	} else {
+		fmt.Fprintln(w, "- **ALPN:** No")
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getMarkdownExpiry returns info about certificate expiry
+func getMarkdownExpiry(checkInfo *HostCheckInfo) string {
+	if checkInfo.info == nil || len(checkInfo.info.Certs) == 0 {
+		return ""
+	}
+
+	cert := checkInfo.info.Certs[0]
+	validDays := getValidDays(cert)
+	result := timeutil.Format(time.Unix(cert.NotAfter/1000, 0), "%Y/%m/%d")
+
+	if validDays < 0 {
+		return result + " " + MD_BADGE_EXPIRED
+	}
+
+	return result + fmt.Sprintf(" (%d days)", validDays)
+}
+
+// mdEscape escapes symbols used in Markdown markup
+func mdEscape(data string) string {
+	return strings.NewReplacer(
+		"|", "\\|", "*", "\\*", "_", "\\_", "`", "\\`",
+	).Replace(data)
+}
+
+// mdValue returns value or dash if value is empty
+func mdValue(data string) string {
+	if data == "" {
+		return "—"
+	}
+
+	return data
+}
@@ -0,0 +1,93 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/options"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	EVENT_QUEUED      = "queued"
+	EVENT_IN_PROGRESS = "in_progress"
+	EVENT_RETRY       = "retry"
+	EVENT_DONE        = "done"
+	EVENT_ERROR       = "error"
+	EVENT_SKIPPED     = "skipped"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CheckEvent contains info about check progress event
+type CheckEvent struct {
+	Event   string         `json:"event"`
+	Host    string         `json:"host"`
+	Time    string         `json:"time"`
+	Message string         `json:"message,omitempty"`
+	Result  *HostCheckInfo `json:"result,omitempty"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isStreamOutput returns true if results must be printed as soon as check is completed
+func isStreamOutput() bool {
+	return options.GetS(OPT_FORMAT) == FORMAT_NDJSON
+}
+
+// emitQueuedEvents prints events for all hosts queued for check
+func emitQueuedEvents(hosts []string) {
+	for _, host := range hosts {
+		encodeEvent(&CheckEvent{Event: EVENT_QUEUED, Host: host})
+	}
+}
+
+// emitProgressEvent prints event with assessment progress message
+func emitProgressEvent(host, message string) {
+	encodeEvent(&CheckEvent{Event: EVENT_IN_PROGRESS, Host: host, Message: message})
+}
+
+// emitRetryEvent prints event with info about request retry
+func emitRetryEvent(host string, err error, attempt int, delay time.Duration) {
+	encodeEvent(&CheckEvent{
+		Event: EVENT_RETRY,
+		Host:  host,
+		Message: fmt.Sprintf(
+			"%v (retry %d in %s)", err, attempt, delay.Round(time.Millisecond),
+		),
+	})
+}
+
+// emitResultEvent prints event with check result
+func emitResultEvent(checkInfo *HostCheckInfo) {
+	event := &CheckEvent{Event: EVENT_DONE, Host: checkInfo.Host, Result: checkInfo}
+
+	if checkInfo.Error != nil {
+		event.Event = EVENT_ERROR
+		event.Message = checkInfo.Error.Message
+	}
+
+	encodeEvent(event)
+}
+
+// encodeEvent prints event as single line JSON object
+func encodeEvent(event *CheckEvent) {
+	event.Time = time.Now().UTC().Format(time.RFC3339)
+
+	jsonData, err := json.Marshal(event)
+
+	if err != nil {
+		return
+	}
+
+	fmt.Println(string(jsonData))
+}
@@ -0,0 +1,429 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// PIN_PREFIX is prefix of allowed issuer defined as SPKI pin
+const PIN_PREFIX = "pin-sha256:"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// certPolicy contains certificate issuance policy
+type certPolicy struct {
+	AllowedIssuers []string
+	BannedKeys     []string
+	MaxValidity    time.Duration
+	RequireCT      bool
+	RequireCAA     bool
+	RequireHeaders []string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// policy contains issuance policy defined by user (nil if policy is not defined)
+var policy *certPolicy
+
+// policyViolations contains policy violations for every checked host
+var policyViolations = make(map[string][]string)
+
+// caaIssuers is map CAA issuer domain → issuer organization names
+var caaIssuers = map[string][]string{
+	"letsencrypt.org":   {"Let's Encrypt"},
+	"pki.goog":          {"Google Trust Services"},
+	"digicert.com":      {"DigiCert", "GeoTrust", "Thawte", "RapidSSL"},
+	"sectigo.com":       {"Sectigo", "COMODO", "ZeroSSL"},
+	"comodoca.com":      {"Sectigo", "COMODO"},
+	"zerossl.com":       {"ZeroSSL"},
+	"globalsign.com":    {"GlobalSign"},
+	"amazon.com":        {"Amazon"},
+	"amazontrust.com":   {"Amazon"},
+	"awstrust.com":      {"Amazon"},
+	"amazonaws.com":     {"Amazon"},
+	"godaddy.com":       {"GoDaddy", "Starfield"},
+	"starfieldtech.com": {"Starfield"},
+	"entrust.net":       {"Entrust"},
+	"buypass.com":       {"Buypass"},
+	"ssl.com":           {"SSL.com"},
+	"certum.pl":         {"Certum", "Unizeto"},
+	"harica.gr":         {"HARICA", "Hellenic Academic"},
+	"microsoft.com":     {"Microsoft"},
+	"actalis.it":        {"Actalis"},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parsePolicy parses issuance policy options
+func parsePolicy() error {
+	p := &certPolicy{
+		AllowedIssuers: splitPolicyList(options.GetS(OPT_ALLOWED_ISSUERS)),
+		BannedKeys:     splitPolicyList(options.GetS(OPT_BANNED_KEYS)),
+		RequireCT:      options.GetB(OPT_REQUIRE_CT),
+		RequireCAA:     options.GetB(OPT_REQUIRE_CAA),
+		RequireHeaders: splitPolicyList(strings.ToLower(options.GetS(OPT_REQUIRE_HEADERS))),
+	}
+
+	for _, issuer := range p.AllowedIssuers {
+		if strings.HasPrefix(issuer, PIN_PREFIX) {
+			continue
+		}
+
+		_, err := path.Match(strings.ToLower(issuer), "")
+
+		if err != nil {
+			return fmt.Errorf("Invalid allowed issuer pattern %q", issuer)
+		}
+	}
+
+	for _, key := range p.BannedKeys {
+		_, _, err := parseBannedKey(key)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, header := range p.RequireHeaders {
+		if !slices.Contains(requirableHeaders, header) {
+			return fmt.Errorf(
+				"Unsupported required security header %q (must be %s)",
+				header, strings.Join(requirableHeaders, ", "),
+			)
+		}
+	}
+
+	if options.GetS(OPT_MAX_VALIDITY) != "" {
+		dur, err := timeutil.ParseDuration(options.GetS(OPT_MAX_VALIDITY), 'd')
+
+		if err != nil {
+			return fmt.Errorf("Can't parse maximum validity period: %w", err)
+		}
+
+		p.MaxValidity = dur
+	}
+
+	if len(p.AllowedIssuers) != 0 || len(p.BannedKeys) != 0 ||
+		p.MaxValidity > 0 || p.RequireCT || p.RequireCAA ||
+		len(p.RequireHeaders) != 0 {
+		policy = p
+	}
+
+	return nil
+}
+
+// checkPolicy checks certificates served by all endpoints and returns list
+// of policy violations
+//
+// If full assessment info is not available, policy is treated as violated.
+func checkPolicy(info *sslscan.AnalyzeInfo) []string {
+	if policy == nil {
+		return nil
+	}
+
+	if info == nil {
+		return []string{"Policy could not be evaluated: full assessment info is not available"}
+	}
+
+	var result []string
+
+	for _, endpoint := range info.Endpoints {
+		for _, violation := range checkHeadersPolicy(endpoint.Details) {
+			if !slices.Contains(result, violation) {
+				result = append(result, violation)
+			}
+		}
+
+		cert := getEndpointCert(info, endpoint)
+
+		if cert == nil {
+			continue
+		}
+
+		for _, violation := range checkCertPolicy(cert, info, endpoint) {
+			violation = fmt.Sprintf("%s: %s", extractSubject(cert.Subject), violation)
+
+			if !slices.Contains(result, violation) {
+				result = append(result, violation)
+			}
+		}
+	}
+
+	return result
+}
+
+// recordPolicyViolations saves policy violations of host for exit code
+// calculation
+func recordPolicyViolations(host string, violations []string) {
+	if len(violations) == 0 {
+		delete(policyViolations, host)
+		return
+	}
+
+	policyViolations[host] = violations
+}
+
+// hasPolicyViolations returns true if certificates of host violate policy
+func hasPolicyViolations(host string) bool {
+	return len(policyViolations[host]) != 0
+}
+
+// checkCertPolicy checks leaf certificate served by endpoint
+func checkCertPolicy(cert *sslscan.Cert, info *sslscan.AnalyzeInfo, endpoint *sslscan.EndpointInfo) []string {
+	var result []string
+
+	issuer := extractSubject(cert.IssuerSubject)
+
+	if len(policy.AllowedIssuers) != 0 && !isIssuerAllowed(cert, info, endpoint) {
+		result = append(result, fmt.Sprintf("Issuer %s is not allowed", issuer))
+	}
+
+	if policy.RequireCT && !cert.SCT && (endpoint.Details == nil || endpoint.Details.HasSCT == 0) {
+		result = append(result, "Certificate Transparency info (SCT) is missing")
+	}
+
+	if policy.RequireCAA {
+		switch {
+		case !cert.DNSCAA || cert.CAAPolicy == nil:
+			result = append(result, "DNS CAA records are missing")
+		case !isCAAAllowsIssuer(cert):
+			violation := fmt.Sprintf(
+				"Issuer %s is not allowed by DNS CAA records (%s)",
+				issuer, strings.Join(getCAAIssuers(cert.CAAPolicy), ", "),
+			)
+
+			unknown := getUnknownCAAIssuers(cert.CAAPolicy)
+
+			if len(unknown) != 0 {
+				violation += fmt.Sprintf(
+					"; issuer domains %s are unknown", strings.Join(unknown, ", "),
+				)
+			}
+
+			result = append(result, violation)
+		}
+	}
+
+	if policy.MaxValidity > 0 {
+		validity := time.Duration(cert.NotAfter-cert.NotBefore) * time.Millisecond
+
+		if validity > policy.MaxValidity {
+			result = append(result, fmt.Sprintf(
+				"Validity period (%d days) is longer than %d days",
+				int(validity.Hours()/24), int(policy.MaxValidity.Hours()/24),
+			))
+		}
+	}
+
+	if isKeyBanned(cert.KeyAlg, cert.KeySize) {
+		result = append(result, fmt.Sprintf("Key %s %d bits is banned", cert.KeyAlg, cert.KeySize))
+	}
+
+	return result
+}
+
+// checkHeadersPolicy checks security headers returned by endpoint
+//
+// Explicitly required header must fully pass the check, so both missing and
+// weak headers are treated as violations.
+func checkHeadersPolicy(details *sslscan.EndpointDetails) []string {
+	if len(policy.RequireHeaders) == 0 {
+		return nil
+	}
+
+	checks := checkSecurityHeaders(details)
+
+	if len(checks) == 0 {
+		return []string{"Security headers could not be evaluated: HTTP response wasn't captured by assessment"}
+	}
+
+	var result []string
+
+	for _, check := range checks {
+		if check.Status != HEADER_STATUS_PASS && slices.Contains(policy.RequireHeaders, check.ID) {
+			result = append(result, fmt.Sprintf("%s: %s", check.Name, check.Message))
+		}
+	}
+
+	return result
+}
+
+// printPolicyViolations checks host certificates and prints policy violations
+func printPolicyViolations(host string, info *sslscan.AnalyzeInfo) {
+	violations := checkPolicy(info)
+
+	recordPolicyViolations(host, violations)
+
+	for _, violation := range violations {
+		fmtc.Printfn("  {s-}└{!} {r}%s{!}", violation)
+	}
+}
+
+// isIssuerAllowed returns true if certificate issuer matches allowed issuer
+// pattern or any certificate from chain or trust paths matches allowed pin
+func isIssuerAllowed(cert *sslscan.Cert, info *sslscan.AnalyzeInfo, endpoint *sslscan.EndpointInfo) bool {
+	var pins []string
+
+	if endpoint.Details != nil {
+		for _, chain := range endpoint.Details.CertChains {
+			var ids []string
+
+			ids = append(ids, chain.CertIDs...)
+
+			for _, trustPath := range chain.TrustPaths {
+				ids = append(ids, trustPath.CertIDs...)
+			}
+
+			for _, id := range ids {
+				chainCert := findCertByID(info.Certs, id)
+
+				if chainCert != nil && chainCert.ID != cert.ID {
+					pins = append(pins, chainCert.PINSHA256)
+				}
+			}
+		}
+	}
+
+	for _, issuer := range policy.AllowedIssuers {
+		if strings.HasPrefix(issuer, PIN_PREFIX) {
+			if slices.Contains(pins, strings.TrimPrefix(issuer, PIN_PREFIX)) {
+				return true
+			}
+
+			continue
+		}
+
+		pattern := strings.ToLower(issuer)
+
+		for _, subject := range []string{extractSubject(cert.IssuerSubject), cert.IssuerSubject} {
+			if ok, _ := path.Match(pattern, strings.ToLower(subject)); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isCAAAllowsIssuer returns true if DNS CAA records allow certificate issuer
+func isCAAAllowsIssuer(cert *sslscan.Cert) bool {
+	issuer := strings.ToLower(cert.IssuerSubject)
+
+	for _, domain := range getCAAIssuers(cert.CAAPolicy) {
+		// Unknown domains can't be matched with issuer name, so they never
+		// allow issuer
+		for _, name := range caaIssuers[domain] {
+			if strings.Contains(issuer, strings.ToLower(name)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getCAAIssuers returns list of issuer domains from CAA "issue" and
+// "issuewild" records
+func getCAAIssuers(caaPolicy *sslscan.CAAPolicy) []string {
+	var result []string
+
+	for _, rec := range caaPolicy.CAARecords {
+		tag := strings.ToLower(rec.Tag)
+
+		if tag != "issue" && tag != "issuewild" {
+			continue
+		}
+
+		// Value can contain parameters (letsencrypt.org; validationmethods=dns-01)
+		domain := strings.TrimSpace(strings.Split(rec.Value, ";")[0])
+		domain = strings.ToLower(domain)
+
+		if domain != "" && !slices.Contains(result, domain) {
+			result = append(result, domain)
+		}
+	}
+
+	return result
+}
+
+// getUnknownCAAIssuers returns list of CAA issuer domains which are not
+// present in caaIssuers map
+func getUnknownCAAIssuers(caaPolicy *sslscan.CAAPolicy) []string {
+	var result []string
+
+	for _, domain := range getCAAIssuers(caaPolicy) {
+		if caaIssuers[domain] == nil {
+			result = append(result, domain)
+		}
+	}
+
+	return result
+}
+
+// isKeyBanned returns true if key with given algorithm and size is banned
+//
+// Size in banned key definition is maximum banned size, so RSA-1024 bans all
+// RSA keys with size 1024 bits or less.
+func isKeyBanned(alg string, size int) bool {
+	for _, key := range policy.BannedKeys {
+		bannedAlg, bannedSize, _ := parseBannedKey(key)
+
+		if strings.EqualFold(bannedAlg, alg) && (bannedSize == 0 || size <= bannedSize) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseBannedKey parses banned key definition (RSA, RSA-1024) and returns
+// algorithm and maximum banned key size (0 if all sizes are banned)
+func parseBannedKey(key string) (string, int, error) {
+	alg, size, hasSize := strings.Cut(key, "-")
+
+	if !hasSize {
+		return alg, 0, nil
+	}
+
+	keySize, err := strconv.Atoi(size)
+
+	if err != nil || keySize <= 0 {
+		return "", 0, fmt.Errorf("Invalid banned key %q (must be alg or alg-maxsize)", key)
+	}
+
+	return alg, keySize, nil
+}
+
+// splitPolicyList splits comma-separated list
+func splitPolicyList(data string) []string {
+	var result []string
+
+	for _, item := range strings.Split(data, ",") {
+		item = strings.TrimSpace(item)
+
+		if item != "" {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
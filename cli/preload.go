@@ -0,0 +1,243 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	PRELOAD_STATUS_PRELOADED = "preloaded"
+	PRELOAD_STATUS_READY     = "ready"
+	PRELOAD_STATUS_NOT_READY = "not-ready"
+	PRELOAD_STATUS_AT_RISK   = "at-risk"
+)
+
+// PRELOAD_MIN_MAX_AGE is minimal HSTS max-age required for preload submission
+// (1 year)
+const PRELOAD_MIN_MAX_AGE = 31536000
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// PreloadReadiness contains info about domain readiness for HSTS preload
+// submission
+type PreloadReadiness struct {
+	Domain      string   `json:"domain"`
+	Status      string   `json:"status"`
+	PreloadedIn []string `json:"preloadedIn,omitempty"`
+	Problems    []string `json:"problems,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getPreloadReadiness checks if host meets HSTS preload submission requirements
+// on all given endpoints
+//
+// Submission requirements are checked only for registrable domain, subdomains
+// can be preloaded only through parent domain with includeSubDomains.
+func getPreloadReadiness(host string, endpoints []*sslscan.EndpointInfo) *PreloadReadiness {
+	var hasDetails bool
+
+	result := &PreloadReadiness{Domain: host}
+	baseDomain := getBaseDomain(host)
+
+	for _, endpoint := range endpoints {
+		if endpoint.Details == nil {
+			continue
+		}
+
+		hasDetails = true
+
+		for _, preload := range endpoint.Details.HSTSPreloads {
+			if preload.Status == sslscan.HSTS_STATUS_PRESENT &&
+				!slices.Contains(result.PreloadedIn, preload.Source) {
+				result.PreloadedIn = append(result.PreloadedIn, preload.Source)
+			}
+		}
+
+		if baseDomain != host {
+			continue
+		}
+
+		if endpoint.Grade == "T" {
+			result.addProblem("Certificate is not trusted")
+		}
+
+		checkPreloadHSTSPolicy(result, endpoint.Details.HSTSPolicy)
+		checkPreloadRedirects(result, host, endpoint.Details.HTTPTransactions)
+	}
+
+	if !hasDetails {
+		return nil
+	}
+
+	if baseDomain != host && len(result.PreloadedIn) == 0 {
+		result.addProblem(fmt.Sprintf(
+			"Only registrable domain can be submitted (%s is subdomain of %s)",
+			host, baseDomain,
+		))
+	}
+
+	switch {
+	case len(result.PreloadedIn) != 0 && len(result.Problems) != 0:
+		result.Status = PRELOAD_STATUS_AT_RISK
+	case len(result.PreloadedIn) != 0:
+		result.Status = PRELOAD_STATUS_PRELOADED
+	case len(result.Problems) != 0:
+		result.Status = PRELOAD_STATUS_NOT_READY
+	default:
+		result.Status = PRELOAD_STATUS_READY
+	}
+
+	return result
+}
+
+// checkPreloadHSTSPolicy checks if HSTS header meets preload requirements
+func checkPreloadHSTSPolicy(result *PreloadReadiness, hsts *sslscan.HSTSPolicy) {
+	if hsts == nil || hsts.Status != sslscan.HSTS_STATUS_PRESENT {
+		result.addProblem("HSTS header is not set or invalid")
+		return
+	}
+
+	if hsts.MaxAge < PRELOAD_MIN_MAX_AGE {
+		result.addProblem(fmt.Sprintf(
+			"HSTS max-age must be at least %d (1 year), current value is %d",
+			PRELOAD_MIN_MAX_AGE, hsts.MaxAge,
+		))
+	}
+
+	if !hsts.IncludeSubDomains {
+		result.addProblem("HSTS header must contain includeSubDomains directive")
+	}
+
+	if !hsts.Preload {
+		result.addProblem("HSTS header must contain preload directive")
+	}
+}
+
+// checkPreloadRedirects checks redirects captured in HTTP transactions
+func checkPreloadRedirects(result *PreloadReadiness, host string, transactions []*sslscan.HTTPTransaction) {
+	var hasHTTPRequest bool
+
+	for _, transaction := range transactions {
+		reqURL, err := url.Parse(transaction.RequestURL)
+
+		if err != nil {
+			continue
+		}
+
+		location := getHeaderValue(transaction.ResponseHeaders, "Location")
+		isRedirect := transaction.StatusCode >= 300 && transaction.StatusCode < 400
+
+		if reqURL.Scheme == "https" {
+			if isRedirect && strings.HasPrefix(strings.ToLower(location), "http://") {
+				result.addProblem("HTTPS redirects to HTTP (" + location + ")")
+			}
+
+			continue
+		}
+
+		if !strings.EqualFold(reqURL.Hostname(), host) {
+			continue
+		}
+
+		hasHTTPRequest = true
+
+		if !isRedirect {
+			result.addProblem("HTTP must redirect to HTTPS")
+			continue
+		}
+
+		redirectURL, err := reqURL.Parse(location)
+
+		switch {
+		case err != nil || redirectURL.Scheme != "https":
+			result.addProblem("HTTP must redirect to HTTPS (redirects to " + location + ")")
+		case !strings.EqualFold(redirectURL.Hostname(), host):
+			result.addProblem("HTTP must redirect to HTTPS on the same host first (redirects to " + location + ")")
+		}
+	}
+
+	if !hasHTTPRequest {
+		result.addWarning("HTTP to HTTPS redirect wasn't captured by assessment")
+	}
+}
+
+// addProblem adds unique problem to readiness info
+func (r *PreloadReadiness) addProblem(problem string) {
+	if !slices.Contains(r.Problems, problem) {
+		r.Problems = append(r.Problems, problem)
+	}
+}
+
+// addWarning adds unique warning to readiness info
+func (r *PreloadReadiness) addWarning(warning string) {
+	if !slices.Contains(r.Warnings, warning) {
+		r.Warnings = append(r.Warnings, warning)
+	}
+}
+
+// appendPreloadReadiness appends HSTS preload readiness info to check info
+func appendPreloadReadiness(checkInfo *HostCheckInfo) {
+	if checkInfo.info == nil {
+		return
+	}
+
+	checkInfo.HSTSPreload = getPreloadReadiness(checkInfo.Host, checkInfo.info.Endpoints)
+}
+
+// printHSTSPreloadInfo prints info about HSTS preload readiness of endpoint
+func printHSTSPreloadInfo(w io.Writer, host string, endpoint *sslscan.EndpointInfo) {
+	readiness := getPreloadReadiness(host, []*sslscan.EndpointInfo{endpoint})
+
+	if readiness == nil {
+		return
+	}
+
+	printCategoryHeader(w, "HSTS Preload")
+
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Preload lists")
+
+	if len(readiness.PreloadedIn) != 0 {
+		fmtc.Fprintfn(w, "{g}%s{!}", strings.Join(readiness.PreloadedIn, " "))
+	} else {
+		fmtc.Fprintln(w, "{s-}—{!}")
+	}
+
+	fmtc.Fprintf(w, " %-40s {s}|{!} ", "Submission readiness")
+
+	switch readiness.Status {
+	case PRELOAD_STATUS_PRELOADED:
+		fmtc.Fprintln(w, "{g}Preloaded{!}")
+	case PRELOAD_STATUS_READY:
+		fmtc.Fprintln(w, "{g}Ready{!}")
+	case PRELOAD_STATUS_AT_RISK:
+		fmtc.Fprintln(w, "{r}Preloaded, but doesn't meet requirements{!}")
+	default:
+		fmtc.Fprintln(w, "{y}Not ready{!}")
+	}
+
+	for _, problem := range readiness.Problems {
+		fmtc.Fprintfn(w, " %-40s {s}|{!} {r}%s{!}", "", problem)
+	}
+
+	for _, warning := range readiness.Warnings {
+		fmtc.Fprintfn(w, " %-40s {s}|{!} {s-}%s{!}", "", warning)
+	}
+}
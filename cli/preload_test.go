@@ -0,0 +1,160 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"testing"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestGetPreloadReadiness(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Host     string
+		Endpoint *sslscan.EndpointInfo
+		Status   string
+		Problems []string
+		Warnings []string
+	}{
+		{
+			Name:     "ready",
+			Host:     "domain.com",
+			Endpoint: getTestPreloadEndpoint(getTestHSTSPolicy(), "https://domain.com/"),
+			Status:   PRELOAD_STATUS_READY,
+		},
+		{
+			Name: "weak HSTS policy",
+			Host: "domain.com",
+			Endpoint: getTestPreloadEndpoint(
+				&sslscan.HSTSPolicy{Status: sslscan.HSTS_STATUS_PRESENT, MaxAge: 86400},
+				"https://domain.com/",
+			),
+			Status: PRELOAD_STATUS_NOT_READY,
+			Problems: []string{
+				"HSTS max-age must be at least 31536000 (1 year), current value is 86400",
+				"HSTS header must contain includeSubDomains directive",
+				"HSTS header must contain preload directive",
+			},
+		},
+		{
+			Name:     "no HSTS header",
+			Host:     "domain.com",
+			Endpoint: getTestPreloadEndpoint(nil, "https://domain.com/"),
+			Status:   PRELOAD_STATUS_NOT_READY,
+			Problems: []string{"HSTS header is not set or invalid"},
+		},
+		{
+			Name:     "redirect to other host",
+			Host:     "domain.com",
+			Endpoint: getTestPreloadEndpoint(getTestHSTSPolicy(), "https://www.domain.com/"),
+			Status:   PRELOAD_STATUS_NOT_READY,
+			Problems: []string{
+				"HTTP must redirect to HTTPS on the same host first (redirects to https://www.domain.com/)",
+			},
+		},
+		{
+			Name:     "redirect to HTTP",
+			Host:     "domain.com",
+			Endpoint: getTestPreloadEndpoint(getTestHSTSPolicy(), "http://domain.com/login"),
+			Status:   PRELOAD_STATUS_NOT_READY,
+			Problems: []string{
+				"HTTP must redirect to HTTPS (redirects to http://domain.com/login)",
+			},
+		},
+		{
+			Name:     "subdomain",
+			Host:     "www.domain.com",
+			Endpoint: getTestPreloadEndpoint(nil, ""),
+			Status:   PRELOAD_STATUS_NOT_READY,
+			Problems: []string{
+				"Only registrable domain can be submitted (www.domain.com is subdomain of domain.com)",
+			},
+		},
+		{
+			Name: "preloaded with problems",
+			Host: "domain.com",
+			Endpoint: &sslscan.EndpointInfo{
+				Grade: "T",
+				Details: &sslscan.EndpointDetails{
+					HSTSPolicy: getTestHSTSPolicy(),
+					HSTSPreloads: []sslscan.HSTSPreload{
+						{Source: "Chrome", Status: sslscan.HSTS_STATUS_PRESENT},
+						{Source: "Firefox", Status: sslscan.HSTS_STATUS_ABSENT},
+					},
+				},
+			},
+			Status:   PRELOAD_STATUS_AT_RISK,
+			Problems: []string{"Certificate is not trusted"},
+			Warnings: []string{"HTTP to HTTPS redirect wasn't captured by assessment"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result := getPreloadReadiness(tc.Host, []*sslscan.EndpointInfo{tc.Endpoint})
+
+			if result == nil {
+				t.Fatal("Expected readiness info, got nil")
+			}
+
+			if result.Status != tc.Status {
+				t.Errorf("Expected status %q, got %q", tc.Status, result.Status)
+			}
+
+			if !slices.Equal(result.Problems, tc.Problems) {
+				t.Errorf("Expected problems %q, got %q", tc.Problems, result.Problems)
+			}
+
+			if tc.Warnings != nil && !slices.Equal(result.Warnings, tc.Warnings) {
+				t.Errorf("Expected warnings %q, got %q", tc.Warnings, result.Warnings)
+			}
+		})
+	}
+
+	endpoints := []*sslscan.EndpointInfo{{Grade: "A"}}
+
+	if result := getPreloadReadiness("domain.com", endpoints); result != nil {
+		t.Errorf("Expected nil for endpoints without details, got %v", result)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getTestHSTSPolicy returns HSTS policy which meets preload requirements
+func getTestHSTSPolicy() *sslscan.HSTSPolicy {
+	return &sslscan.HSTSPolicy{
+		Status:            sslscan.HSTS_STATUS_PRESENT,
+		MaxAge:            PRELOAD_MIN_MAX_AGE,
+		IncludeSubDomains: true,
+		Preload:           true,
+	}
+}
+
+// getTestPreloadEndpoint returns endpoint with given HSTS policy and captured
+// HTTP redirect to given location
+func getTestPreloadEndpoint(hsts *sslscan.HSTSPolicy, location string) *sslscan.EndpointInfo {
+	details := &sslscan.EndpointDetails{HSTSPolicy: hsts}
+
+	if location != "" {
+		details.HTTPTransactions = []*sslscan.HTTPTransaction{
+			{
+				RequestURL: "http://domain.com/",
+				StatusCode: 301,
+				ResponseHeaders: []*sslscan.HTTPHeader{
+					{Name: "Location", Value: location},
+				},
+			},
+		}
+	}
+
+	return &sslscan.EndpointInfo{Grade: "A", Details: details}
+}
@@ -0,0 +1,180 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/terminal"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	PROBE_TIMEOUT = 5 * time.Second
+	PROBE_WORKERS = 16
+)
+
+// noHTTPSHost contains info about host which doesn't serve HTTPS
+type noHTTPSHost struct {
+	Host   string
+	Reason string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isProbeRequired returns true if hosts must be checked for HTTPS availability
+// before assessment
+//
+// Zone files contain all names from domain, and most of them (mail servers,
+// internal services, etc.) usually don't serve HTTPS at all.
+func isProbeRequired() bool {
+	return options.GetS(OPT_IMPORT) == IMPORT_ZONE
+}
+
+// probeHosts splits hosts to hosts which serve HTTPS and hosts which don't
+func probeHosts(hosts []string) ([]string, []noHTTPSHost) {
+	var wg sync.WaitGroup
+
+	reasons := make([]string, len(hosts))
+	workers := make(chan struct{}, PROBE_WORKERS)
+
+	if !options.GetB(OPT_QUIET) && !options.GetB(OPT_FORMAT) {
+		fmtc.TPrintf("{s}Checking HTTPS availability of %d hosts…{!}", len(hosts))
+	}
+
+	for index, host := range hosts {
+		wg.Add(1)
+		workers <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			reasons[index] = probeHTTPS(host)
+			<-workers
+		}()
+	}
+
+	wg.Wait()
+
+	if !options.GetB(OPT_QUIET) && !options.GetB(OPT_FORMAT) {
+		fmtc.TPrintf("")
+	}
+
+	var result []string
+	var noHTTPS []noHTTPSHost
+
+	for index, host := range hosts {
+		if reasons[index] == "" {
+			result = append(result, host)
+			continue
+		}
+
+		noHTTPS = append(noHTTPS, noHTTPSHost{host, reasons[index]})
+
+		if isStreamOutput() {
+			encodeEvent(&CheckEvent{
+				Event:   EVENT_SKIPPED,
+				Host:    host,
+				Message: reasons[index],
+				Result:  getSkippedCheckInfo(host, reasons[index]),
+			})
+		}
+	}
+
+	return result, noHTTPS
+}
+
+// probeHTTPS tries to establish TLS connection with host and returns reason
+// if host doesn't serve HTTPS
+func probeHTTPS(host string) string {
+	dialer := &net.Dialer{Timeout: PROBE_TIMEOUT}
+	conn, err := tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(host, "443"), &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: true, // Certificate will be validated by SSL Labs
+	})
+
+	if err == nil {
+		conn.Close()
+		return ""
+	}
+
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	var opErr *net.OpError
+
+	switch {
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		return "Can't resolve host name"
+	case errors.As(err, &dnsErr):
+		return "Can't resolve host name (" + dnsErr.Err + ")"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "Connection timed out"
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "Connection refused"
+	case errors.Is(err, syscall.EHOSTUNREACH):
+		return "Host is unreachable"
+	case errors.Is(err, syscall.ENETUNREACH):
+		return "Network is unreachable"
+	case errors.As(err, &opErr) && opErr.Op == "dial":
+		return "Connection failed (" + opErr.Err.Error() + ")"
+	}
+
+	return "TLS handshake failed (" + err.Error() + ")"
+}
+
+// getSkippedCheckInfo returns info for host which was skipped because it
+// doesn't serve HTTPS
+//
+// Host has no grade, skip reason is defined by error with "skipped" phase.
+func getSkippedCheckInfo(host, reason string) *HostCheckInfo {
+	return &HostCheckInfo{
+		Host:      host,
+		Endpoints: make([]*EndpointCheckInfo, 0),
+		Error:     newSkippedError(reason),
+		Tags:      hostTags[host],
+	}
+}
+
+// appendSkippedHosts appends info about hosts without HTTPS to checks info
+func appendSkippedHosts(checksInfo []*HostCheckInfo, hosts []noHTTPSHost) []*HostCheckInfo {
+	for _, h := range hosts {
+		checksInfo = append(checksInfo, getSkippedCheckInfo(h.Host, h.Reason))
+	}
+
+	return checksInfo
+}
+
+// printNoHTTPSHosts prints list of hosts which don't serve HTTPS
+func printNoHTTPSHosts(hosts []noHTTPSHost) {
+	if len(hosts) == 0 || options.GetB(OPT_QUIET) || isStreamOutput() {
+		return
+	}
+
+	if options.GetB(OPT_FORMAT) {
+		for _, h := range hosts {
+			terminal.Warn("Skipping %s: %s", h.Host, h.Reason)
+		}
+
+		return
+	}
+
+	fmtc.Printfn("{*}Hosts without HTTPS {s-}(%d){!}\n", len(hosts))
+
+	for _, h := range hosts {
+		fmtc.Printfn("{*}%s{!} {s-}→{!} {s}%s{!}", h.Host, h.Reason)
+	}
+
+	fmtc.NewLine()
+}
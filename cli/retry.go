@@ -0,0 +1,104 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/options"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	RETRY_BASE_DELAY = 2 * time.Second
+	RETRY_MAX_DELAY  = time.Minute
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// retrier contains retry state for requests related to one host
+type retrier struct {
+	Attempts int       // Maximum number of attempts for each request
+	Deadline time.Time // Time after which requests are not retried
+	Count    int       // Number of retries made
+
+	// OnRetry is called before waiting for next attempt
+	OnRetry func(err error, attempt int, delay time.Duration)
+
+	// IsCancelled returns true if requests must not be retried anymore
+	IsCancelled func() bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newRetrier creates new retrier using retry options, retries are not made after
+// given deadline
+func newRetrier(deadline time.Time) *retrier {
+	r := &retrier{
+		Attempts:    max(options.GetI(OPT_RETRIES), 0) + 1,
+		Deadline:    deadline,
+		IsCancelled: isCancelled,
+	}
+
+	if retryDeadline > 0 {
+		r.Deadline = getEarliestTime(r.Deadline, time.Now().Add(retryDeadline))
+	}
+
+	return r
+}
+
+// Do executes given function and retries it on temporary errors using
+// exponential backoff with jitter
+func (r *retrier) Do(fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+
+		if err == nil || !isRetryableError(err) || attempt >= r.Attempts {
+			return err
+		}
+
+		delay := getRetryDelay(attempt)
+
+		if r.IsCancelled() || (!r.Deadline.IsZero() && time.Now().Add(delay).After(r.Deadline)) {
+			return err
+		}
+
+		if r.OnRetry != nil {
+			r.OnRetry(err, attempt, delay)
+		}
+
+		r.Count++
+
+		time.Sleep(delay)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getRetryMessage returns message with number of retries for check result
+func getRetryMessage(retries int) string {
+	switch retries {
+	case 0:
+		return ""
+	case 1:
+		return " {s-}(1 retry){!}"
+	}
+
+	return fmt.Sprintf(" {s-}(%d retries){!}", retries)
+}
+
+// getRetryDelay returns delay before next attempt
+func getRetryDelay(attempt int) time.Duration {
+	delay := min(RETRY_BASE_DELAY<<(attempt-1), RETRY_MAX_DELAY)
+
+	// Use "equal jitter" so delay is always between half and full backoff time
+	return delay/2 + rand.N(delay/2+1)
+}
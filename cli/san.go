@@ -0,0 +1,386 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/pluralize"
+
+	"golang.org/x/net/publicsuffix"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	SAN_MATCH_EXACT    = "exact"
+	SAN_MATCH_WILDCARD = "wildcard"
+	SAN_MATCH_NONE     = "mismatch"
+)
+
+// SAN_LARGE_LIST is number of names after which certificate names list is
+// considered too large
+const SAN_LARGE_LIST = 50
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SANAuditInfo contains info about host coverage by certificate names
+type SANAuditInfo struct {
+	Host             string   `json:"host"`
+	Endpoints        []string `json:"endpoints"`
+	Subject          string   `json:"subject"`
+	SHA256Hash       string   `json:"sha256Hash"`
+	Match            string   `json:"match"`
+	MatchedName      string   `json:"matchedName,omitempty"`
+	NamesCount       int      `json:"namesCount"`
+	UnrelatedDomains []string `json:"unrelatedDomains,omitempty"`
+	InternalNames    []string `json:"internalNames,omitempty"`
+	Warnings         []string `json:"warnings,omitempty"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// internalTLDs contains top-level domains used for internal names
+var internalTLDs = []string{
+	"local", "localdomain", "localhost", "internal", "intranet",
+	"corp", "lan", "home", "private",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// renderSANAudit renders info about coverage of checked hosts by certificate names
+func renderSANAudit(checksInfo []*HostCheckInfo) {
+	audit := getSANAudit(checksInfo)
+
+	switch options.GetS(OPT_FORMAT) {
+	case FORMAT_JSON:
+		encodeSANAuditAsJSON(audit)
+	case FORMAT_YAML:
+		encodeSANAuditAsYAML(audit)
+	case FORMAT_CSV:
+		encodeSANAuditAsCSV(audit, ',')
+	case FORMAT_TSV:
+		encodeSANAuditAsCSV(audit, '\t')
+	default:
+		printSANAudit(audit)
+	}
+}
+
+// getSANAudit checks coverage of every host by names of leaf certificates
+// served by its endpoints
+func getSANAudit(checksInfo []*HostCheckInfo) []*SANAuditInfo {
+	var result []*SANAuditInfo
+
+	for _, checkInfo := range checksInfo {
+		if checkInfo.info == nil {
+			continue
+		}
+
+		var hostAudit []*SANAuditInfo
+
+		for _, endpoint := range checkInfo.info.Endpoints {
+			cert := getEndpointCert(checkInfo.info, endpoint)
+
+			if cert == nil {
+				continue
+			}
+
+			index := slices.IndexFunc(hostAudit, func(a *SANAuditInfo) bool {
+				return a.SHA256Hash == cert.SHA256Hash
+			})
+
+			if index != -1 {
+				hostAudit[index].Endpoints = append(hostAudit[index].Endpoints, endpoint.IPAddress)
+				continue
+			}
+
+			audit := auditCertNames(checkInfo.Host, cert)
+			audit.Endpoints = []string{endpoint.IPAddress}
+			hostAudit = append(hostAudit, audit)
+		}
+
+		result = append(result, hostAudit...)
+	}
+
+	return result
+}
+
+// auditCertNames checks how host is covered by certificate names
+func auditCertNames(host string, cert *sslscan.Cert) *SANAuditInfo {
+	names := getCertNames(cert)
+
+	audit := &SANAuditInfo{
+		Host:       host,
+		Subject:    extractSubject(cert.Subject),
+		SHA256Hash: cert.SHA256Hash,
+		Match:      SAN_MATCH_NONE,
+		NamesCount: len(names),
+	}
+
+	for _, name := range names {
+		switch {
+		case name == host:
+			audit.Match, audit.MatchedName = SAN_MATCH_EXACT, name
+		case audit.Match == SAN_MATCH_NONE && isWildcardMatch(name, host):
+			audit.Match, audit.MatchedName = SAN_MATCH_WILDCARD, name
+		}
+	}
+
+	hostDomain := getBaseDomain(host)
+
+	for _, name := range names {
+		if isInternalName(name) {
+			audit.InternalNames = append(audit.InternalNames, name)
+			continue
+		}
+
+		domain := getBaseDomain(name)
+
+		if domain != hostDomain && !slices.Contains(audit.UnrelatedDomains, domain) {
+			audit.UnrelatedDomains = append(audit.UnrelatedDomains, domain)
+		}
+	}
+
+	if audit.Match == SAN_MATCH_NONE || cert.Issues&8 == 8 {
+		audit.Warnings = append(audit.Warnings, "Host name doesn't match certificate names")
+	}
+
+	if len(audit.UnrelatedDomains) != 0 {
+		audit.Warnings = append(audit.Warnings, fmt.Sprintf(
+			"Certificate is shared with %d unrelated %s",
+			len(audit.UnrelatedDomains),
+			pluralize.Pluralize(len(audit.UnrelatedDomains), "domain", "domains"),
+		))
+	}
+
+	if len(names) > SAN_LARGE_LIST {
+		audit.Warnings = append(audit.Warnings, fmt.Sprintf(
+			"Certificate contains too many names (%d)", len(names),
+		))
+	}
+
+	if len(audit.InternalNames) != 0 {
+		audit.Warnings = append(audit.Warnings, "Certificate contains internal names")
+	}
+
+	return audit
+}
+
+// getCertNames returns unique common and alternative names from certificate
+func getCertNames(cert *sslscan.Cert) []string {
+	var result []string
+
+	for _, name := range append(slices.Clone(cert.CommonNames), cert.AltNames...) {
+		name = strings.TrimSuffix(strings.ToLower(name), ".")
+
+		if name != "" && !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+// isWildcardMatch returns true if wildcard name matches host
+//
+// Wildcard matches only one label (*.domain.com matches www.domain.com, but
+// not a.b.domain.com).
+func isWildcardMatch(name, host string) bool {
+	if !strings.HasPrefix(name, "*.") || !strings.HasSuffix(host, name[1:]) {
+		return false
+	}
+
+	label := strings.TrimSuffix(host, name[1:])
+
+	return label != "" && !strings.Contains(label, ".")
+}
+
+// isInternalName returns true if name looks like internal name
+func isInternalName(name string) bool {
+	name = strings.TrimPrefix(name, "*.")
+
+	if ip := net.ParseIP(name); ip != nil {
+		return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast()
+	}
+
+	if !strings.Contains(name, ".") {
+		return true
+	}
+
+	return slices.Contains(internalTLDs, name[strings.LastIndexByte(name, '.')+1:])
+}
+
+// getBaseDomain returns registrable part of domain name using public suffix list
+func getBaseDomain(name string) string {
+	name = strings.ToLower(strings.TrimPrefix(name, "*."))
+	domain, err := publicsuffix.EffectiveTLDPlusOne(name)
+
+	if err != nil {
+		return name
+	}
+
+	return domain
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// printSANAudit prints SAN audit info to console
+func printSANAudit(audit []*SANAuditInfo) {
+	fmtc.NewLine()
+
+	printCategoryHeader(os.Stdout, "Certificate Names Coverage")
+
+	if len(audit) == 0 {
+		fmtc.Println("\n {s}No certificates found{!}\n")
+		fmtutil.Separator(true)
+		return
+	}
+
+	var mismatched int
+
+	for _, info := range audit {
+		fmtc.Printfn(" %-24s {s}|{!} {*}%s{!} {s-}(%s){!}", "Host", info.Host, strings.Join(info.Endpoints, ", "))
+		fmtc.Printfn(" %-24s {s}|{!} %s", "Subject", info.Subject)
+		fmtc.Printfn(" %-24s {s}|{!} {s-}Fingerprint: %s{!}", "", info.SHA256Hash)
+
+		switch info.Match {
+		case SAN_MATCH_EXACT:
+			fmtc.Printfn(" %-24s {s}|{!} {g}Exact{!} {s-}(%s){!}", "Match", info.MatchedName)
+		case SAN_MATCH_WILDCARD:
+			fmtc.Printfn(" %-24s {s}|{!} {y}Wildcard{!} {s-}(%s){!}", "Match", info.MatchedName)
+		default:
+			fmtc.Printfn(" %-24s {s}|{!} {r}MISMATCH{!}", "Match")
+			mismatched++
+		}
+
+		fmtc.Printfn(" %-24s {s}|{!} %d", "Names", info.NamesCount)
+
+		if len(info.UnrelatedDomains) != 0 {
+			fmtc.Printfn(" %-24s {s}|{!} {y}%s{!}", "Unrelated domains", strings.Join(info.UnrelatedDomains, " "))
+		}
+
+		if len(info.InternalNames) != 0 {
+			fmtc.Printfn(" %-24s {s}|{!} {r}%s{!}", "Internal names", strings.Join(info.InternalNames, " "))
+		}
+
+		for index, warning := range info.Warnings {
+			name := "Warnings"
+
+			if index != 0 {
+				name = ""
+			}
+
+			fmtc.Printfn(" %-24s {s}|{!} {y}%s{!}", name, warning)
+		}
+
+		fmtutil.Separator(true)
+	}
+
+	fmtc.Printfn(
+		"\n {s}%s checked, %d with mismatched names{!}\n",
+		pluralize.P("%d %s", len(audit), "certificate", "certificates"), mismatched,
+	)
+}
+
+// encodeSANAuditAsJSON prints SAN audit info in JSON format
+func encodeSANAuditAsJSON(audit []*SANAuditInfo) {
+	if audit == nil {
+		audit = []*SANAuditInfo{}
+	}
+
+	jsonData, err := json.MarshalIndent(audit, "", "  ")
+
+	if err != nil {
+		fmt.Println("[]")
+		os.Exit(1)
+	}
+
+	fmt.Println(string(jsonData))
+}
+
+// encodeSANAuditAsYAML prints SAN audit info in YAML format
+func encodeSANAuditAsYAML(audit []*SANAuditInfo) {
+	fmt.Println("---")
+	fmt.Println("hosts:")
+
+	for _, info := range audit {
+		fmt.Println("  -")
+		fmt.Printf("    host: %s\n", info.Host)
+		fmt.Println("    endpoints:")
+
+		for _, ip := range info.Endpoints {
+			fmt.Printf("      - \"%s\"\n", ip)
+		}
+
+		fmt.Printf("    subject: %q\n", info.Subject)
+		fmt.Printf("    sha256Hash: %s\n", info.SHA256Hash)
+		fmt.Printf("    match: %s\n", info.Match)
+
+		if info.MatchedName != "" {
+			fmt.Printf("    matchedName: %q\n", info.MatchedName)
+		}
+
+		fmt.Printf("    namesCount: %d\n", info.NamesCount)
+
+		printYAMLList("unrelatedDomains", info.UnrelatedDomains)
+		printYAMLList("internalNames", info.InternalNames)
+		printYAMLList("warnings", info.Warnings)
+	}
+}
+
+// encodeSANAuditAsCSV prints SAN audit info in CSV or TSV format
+func encodeSANAuditAsCSV(audit []*SANAuditInfo, separator rune) {
+	w := csv.NewWriter(os.Stdout)
+	w.Comma = separator
+
+	w.Write([]string{
+		"host", "endpoints", "subject", "sha256", "match", "matched-name",
+		"names-count", "unrelated-domains", "internal-names", "warnings",
+	})
+
+	for _, info := range audit {
+		w.Write([]string{
+			info.Host, strings.Join(info.Endpoints, ", "), info.Subject,
+			info.SHA256Hash, info.Match, info.MatchedName,
+			fmt.Sprintf("%d", info.NamesCount),
+			strings.Join(info.UnrelatedDomains, ", "),
+			strings.Join(info.InternalNames, ", "),
+			strings.Join(info.Warnings, "; "),
+		})
+	}
+
+	w.Flush()
+
+	if w.Error() != nil {
+		os.Exit(1)
+	}
+}
+
+// printYAMLList prints list of strings as YAML property
+func printYAMLList(name string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Printf("    %s:\n", name)
+
+	for _, item := range items {
+		fmt.Printf("      - %q\n", item)
+	}
+}
@@ -0,0 +1,399 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/pluralize"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	SUITE_PROFILE_MODERN       = "modern"
+	SUITE_PROFILE_INTERMEDIATE = "intermediate"
+	SUITE_PROFILE_OLD          = "old"
+)
+
+const (
+	ORDER_SEVERITY_HIGH   = "high"
+	ORDER_SEVERITY_MEDIUM = "medium"
+	ORDER_SEVERITY_LOW    = "low"
+)
+
+// ORDER_MAX_EXAMPLES is maximum number of suites listed in problem description
+const ORDER_MAX_EXAMPLES = 3
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SuiteOrderProblem contains info about cipher suites ordering problem
+type SuiteOrderProblem struct {
+	Severity string `json:"severity"`
+	Protocol string `json:"protocol"`
+	Message  string `json:"message"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// suiteProfiles contains TLS 1.2 and older cipher suites in preferred order
+// for every reference profile (based on Mozilla server side TLS guidelines)
+var suiteProfiles = map[string][]string{
+	SUITE_PROFILE_MODERN: {},
+	SUITE_PROFILE_INTERMEDIATE: {
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+		"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+		"TLS_DHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_DHE_RSA_WITH_AES_256_GCM_SHA384",
+		"TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+	},
+	SUITE_PROFILE_OLD: {
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+		"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+		"TLS_DHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_DHE_RSA_WITH_AES_256_GCM_SHA384",
+		"TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA",
+		"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA384",
+		"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA384",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA",
+		"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA",
+		"TLS_DHE_RSA_WITH_AES_128_CBC_SHA256",
+		"TLS_DHE_RSA_WITH_AES_256_CBC_SHA256",
+		"TLS_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_RSA_WITH_AES_256_GCM_SHA384",
+		"TLS_RSA_WITH_AES_128_CBC_SHA256",
+		"TLS_RSA_WITH_AES_256_CBC_SHA256",
+		"TLS_RSA_WITH_AES_128_CBC_SHA",
+		"TLS_RSA_WITH_AES_256_CBC_SHA",
+		"TLS_RSA_WITH_3DES_EDE_CBC_SHA",
+	},
+}
+
+// orderSeverityRank contains rank of every problem severity used for sorting
+var orderSeverityRank = map[string]int{
+	ORDER_SEVERITY_HIGH:   3,
+	ORDER_SEVERITY_MEDIUM: 2,
+	ORDER_SEVERITY_LOW:    1,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// validateSuiteProfile validates reference cipher suites profile name
+func validateSuiteProfile() error {
+	profile := options.GetS(OPT_SUITE_PROFILE)
+
+	if _, ok := suiteProfiles[profile]; profile == "" || ok {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"Unknown cipher suites profile %q (must be %s, %s or %s)", profile,
+		SUITE_PROFILE_MODERN, SUITE_PROFILE_INTERMEDIATE, SUITE_PROFILE_OLD,
+	)
+}
+
+// getSuiteProfile returns name of reference cipher suites profile
+func getSuiteProfile() string {
+	if options.GetS(OPT_SUITE_PROFILE) == "" {
+		return SUITE_PROFILE_INTERMEDIATE
+	}
+
+	return options.GetS(OPT_SUITE_PROFILE)
+}
+
+// analyzeSuitesOrder checks cipher suites order for all protocols and returns
+// list of problems ranked by severity
+func analyzeSuitesOrder(details *sslscan.EndpointDetails, profile string) []*SuiteOrderProblem {
+	if details == nil {
+		return nil
+	}
+
+	var result []*SuiteOrderProblem
+
+	suites := slices.SortedFunc(slices.Values(details.Suites), func(a, b *sslscan.ProtocolSuites) int {
+		return cmp.Compare(b.Protocol, a.Protocol)
+	})
+
+	for _, protocolSuites := range suites {
+		// In TLS 1.3 all suites are AEAD with forward secrecy and clients
+		// usually choose suite by themselves
+		if protocolSuites.Protocol == sslscan.PROTOCOL_TLS13 || len(protocolSuites.List) == 0 {
+			continue
+		}
+
+		result = append(result, analyzeProtocolSuitesOrder(
+			protocolSuites, details.ChaCha20Preference, profile,
+		)...)
+	}
+
+	slices.SortStableFunc(result, func(a, b *SuiteOrderProblem) int {
+		return cmp.Compare(orderSeverityRank[b.Severity], orderSeverityRank[a.Severity])
+	})
+
+	return result
+}
+
+// analyzeProtocolSuitesOrder checks cipher suites order for one protocol
+func analyzeProtocolSuitesOrder(suites *sslscan.ProtocolSuites, chaCha20Preference bool, profile string) []*SuiteOrderProblem {
+	var result []*SuiteOrderProblem
+
+	protocol := protocolsNames[suites.Protocol]
+
+	addProblem := func(severity, message string) {
+		result = append(result, &SuiteOrderProblem{severity, protocol, message})
+	}
+
+	if !suites.Preference {
+		if len(suites.List) > 1 {
+			addProblem(
+				ORDER_SEVERITY_HIGH,
+				"Server doesn't enforce cipher suites order, so client can choose the weakest suite",
+			)
+		}
+
+		return result
+	}
+
+	if message := findOrderInversions(suites.List, "Insecure suites are preferred over secure ones", isInsecureSuite); message != "" {
+		addProblem(ORDER_SEVERITY_HIGH, message)
+	}
+
+	if message := findOrderInversions(suites.List, "Suites without forward secrecy are preferred over suites with it", isNonFSSuite); message != "" {
+		addProblem(ORDER_SEVERITY_MEDIUM, message)
+	}
+
+	if message := findOrderInversions(suites.List, "CBC suites are preferred over AEAD suites", isNonAEADSuite); message != "" {
+		addProblem(ORDER_SEVERITY_MEDIUM, message)
+	}
+
+	if message := checkChaCha20Order(suites.List, chaCha20Preference); message != "" {
+		addProblem(ORDER_SEVERITY_LOW, message)
+	}
+
+	for _, message := range compareWithProfile(suites.List, profile) {
+		addProblem(ORDER_SEVERITY_LOW, message)
+	}
+
+	return result
+}
+
+// findOrderInversions returns description of problem if any suite matching
+// given function is preferred over suite which doesn't match it
+func findOrderInversions(suites []*sslscan.Suite, problem string, isWorse func(*sslscan.Suite) bool) string {
+	var worse []string
+	var firstBetter string
+
+	for index, suite := range suites {
+		if !isWorse(suite) {
+			continue
+		}
+
+		better := slices.IndexFunc(suites[index+1:], func(s *sslscan.Suite) bool { return !isWorse(s) })
+
+		if better == -1 {
+			continue
+		}
+
+		if firstBetter == "" {
+			firstBetter = suites[index+1+better].Name
+		}
+
+		worse = append(worse, suite.Name)
+	}
+
+	if len(worse) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"%s: %s before %s", problem,
+		formatSuitesList(worse), firstBetter,
+	)
+}
+
+// checkChaCha20Order checks position of ChaCha20 suites relative to AES-GCM
+// suites
+func checkChaCha20Order(suites []*sslscan.Suite, chaCha20Preference bool) string {
+	chachaIndex := slices.IndexFunc(suites, isChaCha20Suite)
+	aesIndex := slices.IndexFunc(suites, func(s *sslscan.Suite) bool {
+		return strings.Contains(s.Name, "_AES_") && strings.Contains(s.Name, "_GCM_")
+	})
+
+	switch {
+	case chachaIndex == -1 || aesIndex == -1:
+		return ""
+	case chachaIndex < aesIndex && !chaCha20Preference:
+		return fmt.Sprintf(
+			"ChaCha20 suite %s is preferred over %s for all clients, clients with AES hardware acceleration should get AES-GCM",
+			suites[chachaIndex].Name, suites[aesIndex].Name,
+		)
+	case chachaIndex > aesIndex && !chaCha20Preference:
+		return "ChaCha20 preference isn't enabled, so clients without AES hardware acceleration (mobile devices) get AES-GCM suites"
+	}
+
+	return ""
+}
+
+// compareWithProfile compares suites order with reference profile
+func compareWithProfile(suites []*sslscan.Suite, profile string) []string {
+	var result, unknown, misplaced []string
+	var firstPair string
+
+	reference := suiteProfiles[profile]
+
+	for index, suite := range suites {
+		rank := slices.Index(reference, suite.Name)
+
+		if rank == -1 {
+			unknown = append(unknown, suite.Name)
+			continue
+		}
+
+		for _, next := range suites[index+1:] {
+			nextRank := slices.Index(reference, next.Name)
+
+			if nextRank == -1 || nextRank > rank {
+				continue
+			}
+
+			if firstPair == "" {
+				firstPair = fmt.Sprintf("%s must be preferred over %s", next.Name, suite.Name)
+			}
+
+			misplaced = append(misplaced, suite.Name)
+
+			break
+		}
+	}
+
+	if len(misplaced) != 0 {
+		result = append(result, fmt.Sprintf(
+			"Order differs from %s profile in %d %s: %s",
+			profile, len(misplaced), pluralize.Pluralize(len(misplaced), "suite", "suites"), firstPair,
+		))
+	}
+
+	if len(unknown) != 0 {
+		result = append(result, fmt.Sprintf(
+			"Suites not included in %s profile: %s",
+			profile, formatSuitesList(unknown),
+		))
+	}
+
+	return result
+}
+
+// formatSuitesList returns list of suites names limited to a few examples
+func formatSuitesList(suites []string) string {
+	if len(suites) <= ORDER_MAX_EXAMPLES {
+		return strings.Join(suites, ", ")
+	}
+
+	return fmt.Sprintf(
+		"%s (+%d more)",
+		strings.Join(suites[:ORDER_MAX_EXAMPLES], ", "),
+		len(suites)-ORDER_MAX_EXAMPLES,
+	)
+}
+
+// isInsecureSuite returns true if suite is insecure
+func isInsecureSuite(suite *sslscan.Suite) bool {
+	insecure, _ := getSuiteSecurity(suite)
+	return insecure
+}
+
+// isNonFSSuite returns true if suite doesn't provide forward secrecy
+func isNonFSSuite(suite *sslscan.Suite) bool {
+	return !strings.Contains(suite.Name, "DHE_")
+}
+
+// isNonAEADSuite returns true if suite doesn't use AEAD cipher
+func isNonAEADSuite(suite *sslscan.Suite) bool {
+	return !strings.Contains(suite.Name, "_GCM_") &&
+		!strings.Contains(suite.Name, "_CCM") &&
+		!isChaCha20Suite(suite)
+}
+
+// isChaCha20Suite returns true if suite uses ChaCha20 cipher
+func isChaCha20Suite(suite *sslscan.Suite) bool {
+	return strings.Contains(suite.Name, "_CHACHA20_")
+}
+
+// appendSuitesOrder appends cipher suites order problems to endpoints info
+func appendSuitesOrder(checkInfo *HostCheckInfo) {
+	if checkInfo.info == nil {
+		return
+	}
+
+	profile := getSuiteProfile()
+
+	for index, endpoint := range checkInfo.info.Endpoints {
+		if index < len(checkInfo.Endpoints) {
+			checkInfo.Endpoints[index].SuitesOrder = analyzeSuitesOrder(endpoint.Details, profile)
+		}
+	}
+}
+
+// formatSuitesOrder returns suites order problems as semicolon-separated list
+func formatSuitesOrder(problems []*SuiteOrderProblem) string {
+	var result []string
+
+	for _, problem := range problems {
+		result = append(result, fmt.Sprintf("%s %s: %s", problem.Severity, problem.Protocol, problem.Message))
+	}
+
+	return strings.Join(result, "; ")
+}
+
+// printSuitesOrderInfo prints cipher suites order analysis
+func printSuitesOrderInfo(w io.Writer, details *sslscan.EndpointDetails) {
+	if len(details.Suites) == 0 {
+		return
+	}
+
+	profile := getSuiteProfile()
+	problems := analyzeSuitesOrder(details, profile)
+
+	printCategoryHeader(w, "Cipher Suites Order")
+
+	if len(problems) == 0 {
+		fmtc.Fprintfn(w, " {g}Cipher suites order matches %s profile{!}", profile)
+		return
+	}
+
+	for index, problem := range problems {
+		switch problem.Severity {
+		case ORDER_SEVERITY_HIGH:
+			fmtc.Fprintfn(w, " {s-}%d{!} {r}%-6s{!} {s}|{!} %-7s {s}|{!} %s", index+1, "High", problem.Protocol, problem.Message)
+		case ORDER_SEVERITY_MEDIUM:
+			fmtc.Fprintfn(w, " {s-}%d{!} {y}%-6s{!} {s}|{!} %-7s {s}|{!} %s", index+1, "Medium", problem.Protocol, problem.Message)
+		default:
+			fmtc.Fprintfn(w, " {s-}%d{!} {s}%-6s{!} {s}|{!} %-7s {s}|{!} %s", index+1, "Low", problem.Protocol, problem.Message)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"testing"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestFindOrderInversions(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Suites   []string
+		Expected string
+	}{
+		{
+			"correct order",
+			[]string{
+				"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+				"TLS_RSA_WITH_AES_128_GCM_SHA256",
+			},
+			"",
+		},
+		{
+			"only worse suites",
+			[]string{
+				"TLS_RSA_WITH_AES_128_GCM_SHA256",
+				"TLS_RSA_WITH_AES_256_GCM_SHA384",
+			},
+			"",
+		},
+		{
+			"inversion",
+			[]string{
+				"TLS_RSA_WITH_AES_128_GCM_SHA256",
+				"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+				"TLS_RSA_WITH_AES_256_GCM_SHA384",
+			},
+			"Non-FS: TLS_RSA_WITH_AES_128_GCM_SHA256 before TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		},
+		{
+			"many inversions",
+			[]string{
+				"TLS_RSA_WITH_AES_128_GCM_SHA256",
+				"TLS_RSA_WITH_AES_256_GCM_SHA384",
+				"TLS_RSA_WITH_AES_128_CBC_SHA",
+				"TLS_RSA_WITH_AES_256_CBC_SHA",
+				"TLS_DHE_RSA_WITH_AES_128_GCM_SHA256",
+			},
+			"Non-FS: TLS_RSA_WITH_AES_128_GCM_SHA256, TLS_RSA_WITH_AES_256_GCM_SHA384, " +
+				"TLS_RSA_WITH_AES_128_CBC_SHA (+1 more) before TLS_DHE_RSA_WITH_AES_128_GCM_SHA256",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result := findOrderInversions(getTestSuites(tc.Suites...), "Non-FS", isNonFSSuite)
+
+			if result != tc.Expected {
+				t.Errorf("Expected %q, got %q", tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestCompareWithProfile(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Profile  string
+		Suites   []string
+		Expected []string
+	}{
+		{
+			"matches profile",
+			SUITE_PROFILE_INTERMEDIATE,
+			[]string{
+				"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+				"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+				"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+			},
+			nil,
+		},
+		{
+			"misplaced suites",
+			SUITE_PROFILE_INTERMEDIATE,
+			[]string{
+				"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+				"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+				"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+			},
+			[]string{
+				"Order differs from intermediate profile in 2 suites: " +
+					"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384 must be preferred over TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+			},
+		},
+		{
+			"unknown suites",
+			SUITE_PROFILE_INTERMEDIATE,
+			[]string{
+				"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+				"TLS_RSA_WITH_AES_128_CBC_SHA",
+			},
+			[]string{"Suites not included in intermediate profile: TLS_RSA_WITH_AES_128_CBC_SHA"},
+		},
+		{
+			"old profile",
+			SUITE_PROFILE_OLD,
+			[]string{
+				"TLS_RSA_WITH_AES_128_CBC_SHA",
+				"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+			},
+			[]string{
+				"Order differs from old profile in 1 suite: " +
+					"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 must be preferred over TLS_RSA_WITH_AES_128_CBC_SHA",
+			},
+		},
+		{
+			"modern profile",
+			SUITE_PROFILE_MODERN,
+			[]string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			[]string{"Suites not included in modern profile: TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result := compareWithProfile(getTestSuites(tc.Suites...), tc.Profile)
+
+			if !slices.Equal(result, tc.Expected) {
+				t.Errorf("Expected %q, got %q", tc.Expected, result)
+			}
+		})
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getTestSuites returns list of suites with given names
+func getTestSuites(names ...string) []*sslscan.Suite {
+	var result []*sslscan.Suite
+
+	for _, name := range names {
+		result = append(result, &sslscan.Suite{Name: name, CipherStrength: 128})
+	}
+
+	return result
+}
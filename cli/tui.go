@@ -0,0 +1,637 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <http://www.apache.org/licenses/LICENSE-2.0>      //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/mathutil"
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"golang.org/x/term"
+
+	sslscan "github.com/essentialkaos/sslscan/v14"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	TUI_LIST_WIDTH = 36
+	TUI_MIN_WIDTH  = 80
+)
+
+const (
+	_TUI_FOCUS_LIST = iota
+	_TUI_FOCUS_DETAILS
+)
+
+const (
+	_KEY_UP        = "\x1b[A"
+	_KEY_DOWN      = "\x1b[B"
+	_KEY_PAGE_UP   = "\x1b[5~"
+	_KEY_PAGE_DOWN = "\x1b[6~"
+	_KEY_ESC       = "\x1b"
+	_KEY_TAB       = "\t"
+	_KEY_ENTER     = "\r"
+	_KEY_BACKSPACE = "\x7f"
+	_KEY_CTRL_C    = "\x03"
+)
+
+const (
+	_CODE_ALT_SCREEN_ON  = "\x1b[?1049h\x1b[?25l"
+	_CODE_ALT_SCREEN_OFF = "\x1b[?25h\x1b[?1049l"
+	_CODE_CLEAR_SCREEN   = "\x1b[H\x1b[2J"
+	_CODE_RESET          = "\x1b[0m"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// tuiPane is collapsible pane with part of detailed info
+type tuiPane struct {
+	Title     string
+	Lines     []string
+	Collapsed bool
+}
+
+// tuiHost contains check info and rendered panes for host
+type tuiHost struct {
+	Check *HostCheckInfo
+	Panes []*tuiPane
+}
+
+// tui contains terminal UI state
+type tui struct {
+	out *os.File
+
+	hosts    []*tuiHost
+	filtered []*tuiHost
+
+	selected int
+	pane     int
+	scroll   int
+	focus    int
+
+	searching bool
+	query     string
+	status    string
+
+	checkHost *tuiHost
+	checkCh   chan *HostCheckInfo
+	checkStop *atomic.Bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// runTUI starts interactive terminal UI for browsing check results
+func runTUI(checksInfo []*HostCheckInfo) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("Terminal UI requires interactive terminal")
+	}
+
+	if len(checksInfo) == 0 {
+		return nil
+	}
+
+	t := &tui{out: os.Stdout}
+
+	for _, checkInfo := range checksInfo {
+		t.hosts = append(t.hosts, &tuiHost{
+			Check: checkInfo,
+			Panes: getTUIPanes(checkInfo.info),
+		})
+	}
+
+	t.applyFilter()
+
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+
+	if err != nil {
+		return fmt.Errorf("Can't switch terminal to raw mode: %w", err)
+	}
+
+	t.out.WriteString(_CODE_ALT_SCREEN_ON)
+
+	keyCh := make(chan string)
+	done := make(chan struct{})
+
+	defer func() {
+		t.stopRecheck()
+		close(done)
+		t.out.WriteString(_CODE_ALT_SCREEN_OFF)
+		term.Restore(int(os.Stdin.Fd()), state)
+	}()
+
+	go readKeys(keyCh, done)
+
+	for {
+		t.render()
+
+		select {
+		case key, ok := <-keyCh:
+			if !ok || !t.processKey(key) {
+				return nil
+			}
+		case checkInfo := <-t.checkCh:
+			t.finishRecheck(checkInfo)
+		}
+	}
+}
+
+// readKeys reads pressed keys from stdin and sends them to given channel
+// until done channel is closed
+func readKeys(keyCh chan<- string, done <-chan struct{}) {
+	buf := make([]byte, 16)
+
+	for {
+		n, err := os.Stdin.Read(buf)
+
+		if err != nil {
+			close(keyCh)
+			return
+		}
+
+		select {
+		case keyCh <- string(buf[:n]):
+		case <-done:
+			return
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// processKey processes pressed key and returns false if UI must be closed
+func (t *tui) processKey(key string) bool {
+	if t.searching {
+		t.processSearchKey(key)
+		return true
+	}
+
+	t.status = ""
+
+	switch key {
+	case "q", _KEY_CTRL_C:
+		return false
+	case _KEY_TAB:
+		if t.focus == _TUI_FOCUS_LIST {
+			t.focus = _TUI_FOCUS_DETAILS
+		} else {
+			t.focus = _TUI_FOCUS_LIST
+		}
+	case _KEY_UP, "k":
+		t.move(-1)
+	case _KEY_DOWN, "j":
+		t.move(1)
+	case _KEY_PAGE_UP:
+		t.scroll = max(t.scroll-t.getBodyHeight()/2, 0)
+	case _KEY_PAGE_DOWN:
+		t.scroll += t.getBodyHeight() / 2
+	case _KEY_ENTER, " ":
+		t.togglePane()
+	case "e":
+		t.setCollapsed(false)
+	case "c":
+		t.setCollapsed(true)
+	case "/":
+		t.searching, t.query = true, ""
+	case "r":
+		t.recheck()
+	case "x":
+		t.cancelRecheck()
+	case _KEY_ESC:
+		t.query = ""
+		t.applyFilter()
+	}
+
+	return true
+}
+
+// processSearchKey processes pressed key in search mode
+func (t *tui) processSearchKey(key string) {
+	switch key {
+	case _KEY_ENTER:
+		t.searching = false
+	case _KEY_ESC, _KEY_CTRL_C:
+		t.searching, t.query = false, ""
+	case _KEY_BACKSPACE:
+		if t.query != "" {
+			t.query = strutil.Substr(t.query, 0, utf8.RuneCountInString(t.query)-1)
+		}
+	default:
+		if strings.HasPrefix(key, "\x1b") || key < " " {
+			return
+		}
+
+		t.query += key
+	}
+
+	t.applyFilter()
+}
+
+// move moves cursor in focused list
+func (t *tui) move(dir int) {
+	if t.focus == _TUI_FOCUS_LIST {
+		t.selected = mathutil.Between(t.selected+dir, 0, max(len(t.filtered)-1, 0))
+		t.pane, t.scroll = 0, 0
+		return
+	}
+
+	host := t.getSelected()
+
+	if host == nil {
+		return
+	}
+
+	t.pane = mathutil.Between(t.pane+dir, 0, max(len(host.Panes)-1, 0))
+	t.scrollToPane()
+}
+
+// togglePane collapses or expands selected pane
+func (t *tui) togglePane() {
+	host := t.getSelected()
+
+	if host == nil || t.focus != _TUI_FOCUS_DETAILS || len(host.Panes) == 0 {
+		return
+	}
+
+	host.Panes[t.pane].Collapsed = !host.Panes[t.pane].Collapsed
+}
+
+// setCollapsed collapses or expands all panes of selected host
+func (t *tui) setCollapsed(collapsed bool) {
+	host := t.getSelected()
+
+	if host == nil {
+		return
+	}
+
+	for _, pane := range host.Panes {
+		pane.Collapsed = collapsed
+	}
+
+	t.scrollToPane()
+}
+
+// recheck starts new assessment for selected host in background
+func (t *tui) recheck() {
+	host := t.getSelected()
+
+	if host == nil {
+		return
+	}
+
+	if t.checkHost != nil {
+		t.status = fmtc.Sprintf("{y}Check for %s is already in progress{!}", t.checkHost.Check.Host)
+		return
+	}
+
+	params := getAnalyzeParams()
+	params.StartNew, params.FromCache = true, false
+
+	// Channel is buffered, so check goroutine will not be blocked if check
+	// was cancelled
+	checkCh := make(chan *HostCheckInfo, 1)
+	checkStop := &atomic.Bool{}
+
+	go func(host string) {
+		_, _, checkInfo := runQuietCheck(host, params, func() bool {
+			return checkStop.Load() || isCancelled()
+		})
+
+		checkCh <- checkInfo
+	}(host.Check.Host)
+
+	t.checkHost, t.checkCh, t.checkStop = host, checkCh, checkStop
+}
+
+// cancelRecheck cancels check in progress and discards its result
+func (t *tui) cancelRecheck() {
+	if t.checkHost == nil {
+		return
+	}
+
+	t.status = fmtc.Sprintf("{y}Check for %s cancelled{!}", t.checkHost.Check.Host)
+	t.stopRecheck()
+}
+
+// stopRecheck stops check in progress
+func (t *tui) stopRecheck() {
+	if t.checkStop != nil {
+		t.checkStop.Store(true)
+	}
+
+	t.checkHost, t.checkCh, t.checkStop = nil, nil, nil
+}
+
+// finishRecheck updates host info with result of finished check and applies
+// check side effects
+func (t *tui) finishRecheck(checkInfo *HostCheckInfo) {
+	host := t.checkHost
+
+	t.checkHost, t.checkCh, t.checkStop = nil, nil, nil
+
+	applyCheckResult(checkInfo)
+
+	if host == t.getSelected() {
+		t.pane, t.scroll = 0, 0
+	}
+
+	host.Check = checkInfo
+	host.Panes = getTUIPanes(checkInfo.info)
+
+	t.status = fmtc.Sprintf(
+		"{g}Check for %s finished at %s{!}",
+		checkInfo.Host, time.Now().Format("15:04:05"),
+	)
+}
+
+// applyFilter filters hosts using search query
+func (t *tui) applyFilter() {
+	t.filtered = nil
+
+	for _, host := range t.hosts {
+		if t.query == "" || strings.Contains(
+			strings.ToLower(host.Check.Host), strings.ToLower(t.query),
+		) {
+			t.filtered = append(t.filtered, host)
+		}
+	}
+
+	t.selected, t.pane, t.scroll = 0, 0, 0
+}
+
+// getSelected returns selected host
+func (t *tui) getSelected() *tuiHost {
+	if len(t.filtered) == 0 {
+		return nil
+	}
+
+	return t.filtered[t.selected]
+}
+
+// scrollToPane scrolls details to make selected pane header visible
+func (t *tui) scrollToPane() {
+	host := t.getSelected()
+
+	if host == nil {
+		return
+	}
+
+	var line int
+
+	for index, pane := range host.Panes {
+		if index == t.pane {
+			break
+		}
+
+		line++
+
+		if !pane.Collapsed {
+			line += len(pane.Lines)
+		}
+	}
+
+	bodyHeight := t.getBodyHeight()
+
+	switch {
+	case line < t.scroll:
+		t.scroll = line
+	case line >= t.scroll+bodyHeight:
+		t.scroll = line - bodyHeight + 1
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// render renders whole UI
+func (t *tui) render() {
+	var buf bytes.Buffer
+
+	width, _ := t.getSize()
+	bodyHeight := t.getBodyHeight()
+	detailsWidth := width - TUI_LIST_WIDTH - 3
+
+	listLines := t.renderHostList(bodyHeight)
+	detailsLines := t.renderDetails(bodyHeight)
+
+	buf.WriteString(_CODE_CLEAR_SCREEN)
+	buf.WriteString(fitLine(fmtc.Sprintf(
+		" {*}%s{!} {s-}%s · %d hosts{!}", APP, VER, len(t.hosts),
+	), width))
+	buf.WriteString("\r\n")
+
+	for i := 0; i < bodyHeight; i++ {
+		buf.WriteString(fitLine(listLines[i], TUI_LIST_WIDTH))
+		buf.WriteString(fmtc.Sprint(" {s}│{!} "))
+		buf.WriteString(fitLine(detailsLines[i], detailsWidth))
+		buf.WriteString("\r\n")
+	}
+
+	switch {
+	case t.searching:
+		buf.WriteString(fitLine(fmtc.Sprintf(" {c}/{!}%s█", t.query), width))
+	case t.status != "":
+		buf.WriteString(fitLine(" "+t.status, width))
+	case t.checkHost != nil:
+		buf.WriteString(fitLine(fmtc.Sprintf(
+			" {s}Checking %s… {s-}(x cancel){!}", t.checkHost.Check.Host,
+		), width))
+	default:
+		buf.WriteString(fitLine(fmtc.Sprint(
+			" {s-}↑↓ move · Tab switch pane · Enter fold · e/c expand/collapse all · / search · r re-check · x cancel check · q quit{!}",
+		), width))
+	}
+
+	t.out.Write(buf.Bytes())
+}
+
+// renderHostList renders list of hosts with grades and expiry info
+func (t *tui) renderHostList(height int) []string {
+	result := make([]string, height)
+
+	if len(t.filtered) == 0 {
+		result[0] = fmtc.Sprint(" {s-}No hosts found{!}")
+		return result
+	}
+
+	offset := max(t.selected-height+1, 0)
+
+	for i := 0; i < height && i+offset < len(t.filtered); i++ {
+		index := i + offset
+		check := t.filtered[index].Check
+
+		marker, hostTag := "  ", ""
+
+		if index == t.selected {
+			marker = "▸ "
+
+			if t.focus == _TUI_FOCUS_LIST {
+				hostTag = "{*}"
+			}
+		}
+
+		result[i] = marker + fmtc.Sprintf(
+			hostTag+"%-22s{!} %s %s",
+			strutil.Ellipsis(check.Host, 22),
+			fitLine(fmtc.Sprint(getColoredGrade(check.LowestGrade)), 3),
+			getTUIExpiry(check.info),
+		)
+	}
+
+	return result
+}
+
+// renderDetails renders panes with detailed info for selected host
+func (t *tui) renderDetails(height int) []string {
+	var lines []string
+
+	result := make([]string, height)
+	host := t.getSelected()
+
+	if host == nil {
+		return result
+	}
+
+	if len(host.Panes) == 0 {
+		if host.Check.Error != nil {
+			result[0] = fmtc.Sprintf(" {r}%s{!}", host.Check.Error.Error())
+		} else {
+			result[0] = fmtc.Sprint(" {s-}No detailed info available{!}")
+		}
+
+		return result
+	}
+
+	for index, pane := range host.Panes {
+		marker, tag := "▾", "{*}"
+
+		if pane.Collapsed {
+			marker = "▸"
+		}
+
+		if index == t.pane && t.focus == _TUI_FOCUS_DETAILS {
+			tag = "{*}{c}"
+		}
+
+		lines = append(lines, fmtc.Sprintf(tag+"%s %s{!}", marker, strings.ToUpper(pane.Title)))
+
+		if !pane.Collapsed {
+			lines = append(lines, pane.Lines...)
+		}
+	}
+
+	t.scroll = mathutil.Between(t.scroll, 0, max(len(lines)-height, 0))
+
+	copy(result, lines[t.scroll:])
+
+	return result
+}
+
+// getSize returns terminal size
+func (t *tui) getSize() (int, int) {
+	width, height, err := term.GetSize(int(t.out.Fd()))
+
+	if err != nil {
+		return 120, 40
+	}
+
+	return max(width, TUI_MIN_WIDTH), max(height, 10)
+}
+
+// getBodyHeight returns height of area with host list and details
+func (t *tui) getBodyHeight() int {
+	_, height := t.getSize()
+	return height - 2
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getTUIPanes creates UI panes with detailed info sections
+func getTUIPanes(info *sslscan.AnalyzeInfo) []*tuiPane {
+	var result []*tuiPane
+
+	for _, section := range getDetailedSections(info) {
+		title := section.Title
+
+		if section.Endpoint != "" {
+			title = section.Endpoint + " · " + title
+		}
+
+		result = append(result, &tuiPane{
+			Title:     title,
+			Lines:     section.Lines,
+			Collapsed: true,
+		})
+	}
+
+	return result
+}
+
+// getTUIExpiry returns colored number of days before certificate expiry
+func getTUIExpiry(info *sslscan.AnalyzeInfo) string {
+	if info == nil || len(info.Certs) == 0 {
+		return fmtc.Sprint("{s-}—{!}")
+	}
+
+	validDays := getValidDays(info.Certs[0])
+
+	switch {
+	case validDays < 0:
+		return fmtc.Sprint("{r}expired{!}")
+	case maxLeftToExpiry > 0 && time.Duration(validDays)*24*time.Hour <= maxLeftToExpiry:
+		return fmtc.Sprintf("{r}%dd{!}", validDays)
+	case validDays <= 30:
+		return fmtc.Sprintf("{y}%dd{!}", validDays)
+	}
+
+	return fmtc.Sprintf("{s-}%dd{!}", validDays)
+}
+
+// fitLine truncates or pads line with ANSI escape codes to given visible width
+func fitLine(line string, width int) string {
+	var buf strings.Builder
+	var size int
+	var escape bool
+
+	for _, r := range line {
+		switch {
+		case r == '\x1b':
+			escape = true
+			buf.WriteRune(r)
+			continue
+		case escape:
+			buf.WriteRune(r)
+
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				escape = false
+			}
+
+			continue
+		case size >= width:
+			continue
+		}
+
+		buf.WriteRune(r)
+		size++
+	}
+
+	buf.WriteString(_CODE_RESET)
+
+	if size < width {
+		buf.WriteString(strings.Repeat(" ", width-size))
+	}
+
+	return buf.String()
+}
@@ -5,6 +5,9 @@ go 1.23.6
 require (
 	github.com/essentialkaos/ek/v13 v13.26.2
 	github.com/essentialkaos/sslscan/v14 v14.1.2
+	golang.org/x/net v0.41.0
+	golang.org/x/term v0.32.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (